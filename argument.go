@@ -1,21 +1,29 @@
 package commander
 
 import (
-	"log"
-	"os"
+	"errors"
+	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
-
-	"github.com/mitchellh/go-homedir"
+	"time"
 )
 
 type argumentTypeFunction func(string) (interface{}, error)
 
-var argumentTypeList map[string]argumentTypeFunction
+// RegisterArgumentType registers a new argument type shared by
+// all CommandRegistry. It fails with a *DuplicateTypeError if the
+// name is already registered, use CommandRegistry.Types to register
+// types only for one registry.
+func RegisterArgumentType(name string, f argumentTypeFunction) error {
+	return sharedTypes.Register(name, f)
+}
 
-// RegisterArgumentType registers a new argument type
-func RegisterArgumentType(name string, f argumentTypeFunction) {
-	argumentTypeList[name] = f
+// RegisterListArgumentType registers a new argument type
+// that accepts comma separated values. If the option is repeated
+// (--set a=1 --set b=2), all values are joined with commas.
+func RegisterListArgumentType(name string, f argumentTypeFunction) error {
+	return sharedTypes.RegisterList(name, f)
 }
 
 // Argument represents a single argument
@@ -26,46 +34,181 @@ type Argument struct {
 	Value         interface{}
 	Error         error
 	FailOnError   bool
+	// Required arguments have to be passed (or have a Default)
+	// and have to be valid
+	Required bool
+	// Short is an optional single letter alias:
+	// "o" accepts -o value, -ovalue and -o=value for --output
+	Short string
+	// Description is displayed next to the option in CommandHelp
+	Description string
+	// Placeholder is the name of the value in CommandHelp (--output=FILE),
+	// Type is used if it's empty
+	Placeholder string
+	// Group is the section where the option is listed in CommandHelp,
+	// options without Group are listed under "Options"
+	Group string
+	// Choices is the list of accepted values, any value is accepted if empty
+	Choices []string
+	// IgnoreCase enables case-insensitive matching of Choices
+	IgnoreCase bool
+	// Env is an environment variable used as value if the option
+	// is not passed, before Default
+	Env string
+	// Default is used as value if the option is not passed
+	Default string
+	// Min is the minimum value, converted with Type (numbers, durations, times)
+	Min string
+	// Max is the maximum value, converted with Type (numbers, durations, times)
+	Max string
+	// MinLength is the minimum length of strings, lists and maps
+	MinLength int
+	// MaxLength is the maximum length of strings, lists and maps, 0 is unlimited
+	MaxLength int
+	// Pattern is a regular expression the value has to match
+	Pattern string
+	// Check is a custom constraint on the converted value
+	Check CheckFunc
+	// Secret values are masked in all output of the library and
+	// can be read from a file with --name-file=path (- for stdin).
+	// Opt returns with SecretMask, TypedOpt and Get with a SecretValue,
+	// use GetSecret or SecretValue.Reveal to get the value.
+	Secret bool
+	// Source is where the value is coming from, set by CommandHelper
+	Source Source
+
+	// types is the TypeRegistry of the CommandHelper
+	// the argument is attached to
+	types *TypeRegistry
 }
 
 // SetValue saves the original value to the argument.
-// Returns with an error if conversion failed
+// Returns with an error if conversion failed, the value
+// is not one of the Choices or violates a constraint
 func (a *Argument) SetValue(original string) error {
 	a.OriginalValue = original
-	a.Value, a.Error = argumentTypeList[a.Type](a.OriginalValue)
+
+	convert := a.converter()
+	if convert == nil {
+		a.Value, a.Error = nil, fmt.Errorf("%w: %s", ErrUnknownArgumentType, a.Type)
+		return a.Error
+	}
+
+	value, err := a.matchChoice(original)
+	a.Value, a.Error = convert(value)
+	if a.Error == nil {
+		if err == nil {
+			err = a.checkConstraints()
+		}
+
+		// a rejected value is never exposed, converters
+		// return with their own zero value on error
+		if err != nil {
+			a.Value = zeroValue(a.Value)
+		}
+	}
+
+	if err != nil {
+		a.Error = err
+	}
 
 	return a.Error
 }
 
-func init() {
-	argumentTypeList = map[string]argumentTypeFunction{}
+// zeroValue returns with the zero value of the type of value,
+// or nil if value is nil
+func zeroValue(value interface{}) interface{} {
+	if value == nil {
+		return nil
+	}
 
-	RegisterArgumentType("String", func(value string) (interface{}, error) {
+	return reflect.Zero(reflect.TypeOf(value)).Interface()
+}
+
+// converter returns with the converter of Type from the
+// attached TypeRegistry, or from the shared types
+func (a *Argument) converter() argumentTypeFunction {
+	return a.typeRegistry().converter(a.Type)
+}
+
+// typeRegistry returns with the registry the Type is looked up in
+func (a *Argument) typeRegistry() *TypeRegistry {
+	if a.types == nil {
+		return sharedTypes
+	}
+
+	return a.types
+}
+
+// matchChoice returns with the matching item from Choices,
+// or an error with the list of allowed values
+func (a *Argument) matchChoice(value string) (string, error) {
+	if len(a.Choices) < 1 {
+		return value, nil
+	}
+
+	for _, choice := range a.Choices {
+		if choice == value || (a.IgnoreCase && strings.EqualFold(choice, value)) {
+			return choice, nil
+		}
+	}
+
+	return value, fmt.Errorf("must be one of: %s", strings.Join(a.Choices, ", "))
+}
+
+func init() {
+	registerBuiltinType("String", func(value string) (interface{}, error) {
 		return value, nil
 	})
 
-	RegisterArgumentType("Int64", func(value string) (interface{}, error) {
+	registerBuiltinType("Int64", func(value string) (interface{}, error) {
 		return strconv.ParseInt(value, 10, 64)
 	})
 
-	RegisterArgumentType("Uint64", func(value string) (interface{}, error) {
+	registerBuiltinType("Uint64", func(value string) (interface{}, error) {
 		return strconv.ParseUint(value, 10, 64)
 	})
 
-	RegisterArgumentType("StringArray[]", func(value string) (interface{}, error) {
-		arr := strings.Split(value, ",")
+	registerBuiltinType("Bool", func(value string) (interface{}, error) {
+		switch strings.ToLower(value) {
+		case "1", "t", "true", "y", "yes", "on":
+			return true, nil
+		case "0", "f", "false", "n", "no", "off":
+			return false, nil
+		}
+
+		return false, errors.New("expected a boolean: true/false, yes/no, on/off or 1/0")
+	})
+
+	registerBuiltinType("Float64", func(value string) (interface{}, error) {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return float64(0), errors.New("expected a floating point number like 3.14 or 1e-3")
+		}
 
-		return arr, nil
+		return number, nil
 	})
 
-	RegisterArgumentType("FilePath", func(value string) (interface{}, error) {
-		value, _ = homedir.Expand(value)
-		_, err := os.Stat(value)
+	registerBuiltinType("Duration", func(value string) (interface{}, error) {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return time.Duration(0), errors.New("expected a duration like 30s, 1.5m or 2h45m")
+		}
+
+		return duration, nil
+	})
 
-		if os.IsNotExist(err) {
-			log.Println(err)
-			value = ""
+	registerBuiltinType("Time", func(value string) (interface{}, error) {
+		for _, layout := range []string{time.RFC3339, "2006-01-02"} {
+			if t, err := time.Parse(layout, value); err == nil {
+				return t, nil
+			}
 		}
-		return value, err
+
+		return time.Time{}, errors.New("expected a time in RFC3339 (2006-01-02T15:04:05Z07:00) or date-only (2006-01-02) format")
+	})
+
+	registerBuiltinListType("StringArray[]", func(value string) (interface{}, error) {
+		return splitList(value)
 	})
 }
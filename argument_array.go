@@ -0,0 +1,67 @@
+package commander
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const arrayTypeSuffix = "[]"
+
+// splitList splits a comma separated list following CSV quoting rules,
+// unquoted items are trimmed and an empty value is an empty list
+func splitList(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return []string{}, nil
+	}
+
+	items, err := splitCSV(value)
+	if err != nil {
+		return []string{}, err
+	}
+
+	return items, nil
+}
+
+// parseArray converts all items with the element converter.
+// The result is a typed slice of the common type of the items,
+// or []interface{} if the types differ or the list is empty.
+func parseArray(value string, element argumentTypeFunction) (interface{}, error) {
+	items, err := splitList(value)
+	if err != nil {
+		return []interface{}{}, err
+	}
+
+	values := make([]interface{}, 0, len(items))
+	for index, item := range items {
+		converted, err := element(item)
+		if err != nil {
+			return []interface{}{}, fmt.Errorf("item %d (%s): %s", index+1, item, err)
+		}
+
+		values = append(values, converted)
+	}
+
+	return typedSlice(values), nil
+}
+
+// typedSlice returns with a slice of the common type of values,
+// or with []interface{} if the types differ, a value is nil
+// or values is empty
+func typedSlice(values []interface{}) interface{} {
+	if len(values) < 1 || values[0] == nil {
+		return values
+	}
+
+	itemType := reflect.TypeOf(values[0])
+	result := reflect.MakeSlice(reflect.SliceOf(itemType), 0, len(values))
+	for _, value := range values {
+		if value == nil || reflect.TypeOf(value) != itemType {
+			return values
+		}
+
+		result = reflect.Append(result, reflect.ValueOf(value))
+	}
+
+	return result.Interface()
+}
@@ -0,0 +1,65 @@
+package commander
+
+import (
+	"net"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArgument_SetValue_array(t *testing.T) {
+	RegisterArgumentType("NilOnError", func(value string) (interface{}, error) {
+		if value == "" {
+			return nil, nil
+		}
+		return value + "!", nil
+	})
+
+	tests := []struct {
+		name      string
+		argType   string
+		parameter string
+		want      interface{}
+		wantErr   string
+	}{
+		{name: "StringArray[]", argType: "StringArray[]", parameter: "one, two ,three", want: []string{"one", "two", "three"}},
+		{name: "StringArray[] [quoted]", argType: "StringArray[]", parameter: `one,"two, three"`, want: []string{"one", "two, three"}},
+		{name: "StringArray[] [escaped quote]", argType: "StringArray[]", parameter: `"say ""hi"""`, want: []string{`say "hi"`}},
+		{name: "StringArray[] [quoted spaces]", argType: "StringArray[]", parameter: `" a ",b , " c"`, want: []string{" a ", "b", " c"}},
+		{name: "StringArray[] [quoted line break]", argType: "StringArray[]", parameter: "\"a\nb\",c", want: []string{"a\nb", "c"}},
+		{name: "StringArray[] [bare quote]", argType: "StringArray[]", parameter: `a"b`, want: []string{}, wantErr: `invalid quoting: unexpected quote in "a\"b", quote the whole value`},
+		{name: "StringArray[] [after quote]", argType: "StringArray[]", parameter: `"a"b,c`, want: []string{}, wantErr: `invalid quoting: unexpected 'b' after the closing quote`},
+		{name: "StringArray[] [unterminated]", argType: "StringArray[]", parameter: `"a,b`, want: []string{}, wantErr: "invalid quoting: missing closing quote"},
+		{name: "StringArray[] [empty]", argType: "StringArray[]", parameter: "", want: []string{}},
+		{name: "StringArray[] [line break]", argType: "StringArray[]", parameter: "a\nb", want: []string{}, wantErr: "unexpected line break, quote values containing line breaks"},
+		{name: "String[]", argType: "String[]", parameter: "a,b", want: []string{"a", "b"}},
+		{name: "Int64[]", argType: "Int64[]", parameter: "1, 2,-3", want: []int64{1, 2, -3}},
+		{name: "Int64[] [invalid]", argType: "Int64[]", parameter: "1,x", want: []interface{}{}, wantErr: `item 2 (x): strconv.ParseInt: parsing "x": invalid syntax`},
+		{name: "IP[]", argType: "IP[]", parameter: "10.0.0.1,::1", want: []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("::1")}},
+		{name: "Duration[]", argType: "Duration[]", parameter: "1s,2m", want: []time.Duration{time.Second, 2 * time.Minute}},
+		{name: "Typed from items", argType: "NilOnError[]", parameter: "a,b", want: []string{"a!", "b!"}},
+		{name: "Nil item", argType: "NilOnError[]", parameter: `a,""`, want: []interface{}{"a!", nil}},
+		{name: "JSON[] [mixed types]", argType: "JSON[]", parameter: `1,"""x"""`, want: []interface{}{float64(1), "x"}},
+		{name: "JSON[] [same type]", argType: "JSON[]", parameter: "1,2", want: []float64{1, 2}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: tt.argType}
+			err := a.SetValue(tt.parameter)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Argument.SetValue() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(a.Value, tt.want) {
+				t.Errorf("Argument.Value = %#v, want %#v", a.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeRegistry_Has_unknown(t *testing.T) {
+	for _, name := range []string{"Unknown", "Unknown[]"} {
+		if sharedTypes.Has(name) {
+			t.Errorf("TypeRegistry.Has(%s) should be false", name)
+		}
+	}
+}
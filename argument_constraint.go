@@ -0,0 +1,211 @@
+package commander
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+	"unicode/utf8"
+)
+
+// CheckFunc is a custom constraint on the converted value of an Argument
+type CheckFunc func(value interface{}) error
+
+// checkConstraints validates the converted value
+// against the constraints of the argument
+func (a *Argument) checkConstraints() error {
+	if a.Min != "" {
+		if err := a.checkBound(a.Min, "Min", -1, "must be at least %s"); err != nil {
+			return err
+		}
+	}
+
+	if a.Max != "" {
+		if err := a.checkBound(a.Max, "Max", 1, "must be at most %s"); err != nil {
+			return err
+		}
+	}
+
+	if a.MinLength > 0 || a.MaxLength > 0 {
+		length, unit, ok := valueLength(a.Value)
+		if !ok {
+			return fmt.Errorf("length of %s cannot be checked", a.Type)
+		}
+
+		if length < a.MinLength {
+			return fmt.Errorf("must be at least %d %s long", a.MinLength, unit)
+		}
+
+		if a.MaxLength > 0 && length > a.MaxLength {
+			return fmt.Errorf("must be at most %d %s long", a.MaxLength, unit)
+		}
+	}
+
+	if a.Pattern != "" {
+		pattern, err := regexp.Compile(a.Pattern)
+		if err != nil {
+			return fmt.Errorf("invalid Pattern: %s", err)
+		}
+
+		value, ok := a.Value.(string)
+		if !ok {
+			value = a.OriginalValue
+		}
+
+		if !pattern.MatchString(value) {
+			return fmt.Errorf("must match %s", a.Pattern)
+		}
+	}
+
+	if a.Check != nil {
+		return a.Check(a.Value)
+	}
+
+	return nil
+}
+
+// checkBound converts the bound with the type of the argument and fails
+// if the value is on the wrong side (-1: less than, 1: greater than)
+func (a *Argument) checkBound(bound, field string, wrongSide int, message string) error {
+	converted, err := a.converter()(bound)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %s", field, err)
+	}
+
+	result, ok := compareValues(a.Value, converted)
+	if !ok {
+		return fmt.Errorf("%s cannot be compared", a.Type)
+	}
+
+	if result == wrongSide {
+		return fmt.Errorf(message, formatValue(converted, bound))
+	}
+
+	return nil
+}
+
+// constraintDescriptions returns with the human-readable constraints
+func (a *Argument) constraintDescriptions() []string {
+	descriptions := []string{}
+
+	if a.Min != "" || a.Max != "" {
+		descriptions = append(descriptions, fmt.Sprintf("range: %s..%s", a.formatBound(a.Min), a.formatBound(a.Max)))
+	}
+
+	if a.MinLength > 0 || a.MaxLength > 0 {
+		maximum := ""
+		if a.MaxLength > 0 {
+			maximum = fmt.Sprintf("%d", a.MaxLength)
+		}
+		descriptions = append(descriptions, fmt.Sprintf("length: %d..%s", a.MinLength, maximum))
+	}
+
+	if a.Pattern != "" {
+		descriptions = append(descriptions, "pattern: "+a.Pattern)
+	}
+
+	return descriptions
+}
+
+// formatBound returns with the human-readable form of
+// a bound (Min, Max or Default) converted with Type.
+// Converters touching the file system are not called.
+func (a *Argument) formatBound(bound string) string {
+	if bound == "" || a.typeRegistry().hasSideEffects(a.Type) {
+		return bound
+	}
+
+	convert := a.converter()
+	if convert == nil {
+		return bound
+	}
+
+	converted, err := convert(bound)
+	if err != nil {
+		return bound
+	}
+
+	return formatValue(converted, bound)
+}
+
+// formatValue returns with the String() of value if it's a Stringer
+func formatValue(value interface{}, original string) string {
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339)
+	}
+
+	if stringer, ok := value.(fmt.Stringer); ok {
+		return stringer.String()
+	}
+
+	return original
+}
+
+// compareValues compares numeric values and times,
+// returns -1 if a < b, 1 if a > b and 0 if they are equal
+func compareValues(a, b interface{}) (int, bool) {
+	if timeA, ok := a.(time.Time); ok {
+		timeB, ok := b.(time.Time)
+		if !ok {
+			return 0, false
+		}
+
+		switch {
+		case timeA.Before(timeB):
+			return -1, true
+		case timeA.After(timeB):
+			return 1, true
+		}
+
+		return 0, true
+	}
+
+	numberA, ok := toFloat(a)
+	if !ok {
+		return 0, false
+	}
+
+	numberB, ok := toFloat(b)
+	if !ok {
+		return 0, false
+	}
+
+	switch {
+	case numberA < numberB:
+		return -1, true
+	case numberA > numberB:
+		return 1, true
+	}
+
+	return 0, true
+}
+
+func toFloat(value interface{}) (float64, bool) {
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+
+	return 0, false
+}
+
+// valueLength returns with the number of characters in a string
+// or the number of items in a list or map
+func valueLength(value interface{}) (int, string, bool) {
+	if text, ok := value.(string); ok {
+		return utf8.RuneCountInString(text), "characters", true
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return v.Len(), "items", true
+	}
+
+	return 0, "", false
+}
@@ -0,0 +1,90 @@
+package commander
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestArgument_SetValue_constraints(t *testing.T) {
+	tests := []struct {
+		name      string
+		argument  Argument
+		parameter string
+		wantErr   string
+	}{
+		{name: "Min ok", argument: Argument{Type: "Int64", Min: "1", Max: "65535"}, parameter: "1"},
+		{name: "Min", argument: Argument{Type: "Int64", Min: "1", Max: "65535"}, parameter: "0", wantErr: "must be at least 1"},
+		{name: "Max", argument: Argument{Type: "Uint64", Min: "1", Max: "65535"}, parameter: "65536", wantErr: "must be at most 65535"},
+		{name: "Float64", argument: Argument{Type: "Float64", Max: "0.5"}, parameter: "0.75", wantErr: "must be at most 0.5"},
+		{name: "Duration", argument: Argument{Type: "Duration", Min: "1s"}, parameter: "500ms", wantErr: "must be at least 1s"},
+		{name: "ByteSize", argument: Argument{Type: "ByteSize", Max: "1GiB"}, parameter: "2GB", wantErr: "must be at most 1 GiB"},
+		{name: "Time", argument: Argument{Type: "Time", Min: "2019-01-01"}, parameter: "2018-12-31", wantErr: "must be at least 2019-01-01T00:00:00Z"},
+		{name: "Invalid Min", argument: Argument{Type: "Int64", Min: "one"}, parameter: "1", wantErr: `invalid Min: strconv.ParseInt: parsing "one": invalid syntax`},
+		{name: "Not comparable", argument: Argument{Type: "String", Min: "a"}, parameter: "b", wantErr: "String cannot be compared"},
+		{name: "MinLength", argument: Argument{Type: "String", MinLength: 3}, parameter: "ab", wantErr: "must be at least 3 characters long"},
+		{name: "MaxLength", argument: Argument{Type: "String", MaxLength: 3}, parameter: "abcd", wantErr: "must be at most 3 characters long"},
+		{name: "MaxLength list", argument: Argument{Type: "Int64[]", MaxLength: 2}, parameter: "1,2,3", wantErr: "must be at most 2 items long"},
+		{name: "Length not supported", argument: Argument{Type: "Int64", MaxLength: 2}, parameter: "1", wantErr: "length of Int64 cannot be checked"},
+		{name: "Pattern ok", argument: Argument{Type: "String", Pattern: "^[a-z-]+$"}, parameter: "my-name"},
+		{name: "Pattern", argument: Argument{Type: "String", Pattern: "^[a-z-]+$"}, parameter: "My Name", wantErr: "must match ^[a-z-]+$"},
+		{name: "Invalid Pattern", argument: Argument{Type: "String", Pattern: "["}, parameter: "a", wantErr: "invalid Pattern: error parsing regexp: missing closing ]: `[`"},
+		{
+			name: "Check",
+			argument: Argument{Type: "Int64", Check: func(value interface{}) error {
+				if value.(int64)%2 != 0 {
+					return errors.New("must be even")
+				}
+				return nil
+			}},
+			parameter: "3",
+			wantErr:   "must be even",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.argument
+			err := a.SetValue(tt.parameter)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Argument.SetValue() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestArgument_SetValue_rejectedValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		argument Argument
+		want     interface{}
+	}{
+		{name: "Choices", argument: Argument{Type: "String", Choices: []string{"json"}}, want: ""},
+		{name: "Typed choices", argument: Argument{Type: "Int64", Choices: []string{"1", "2"}}, want: int64(0)},
+		{name: "Max", argument: Argument{Type: "Int64", Max: "2"}, want: int64(0)},
+		{name: "MaxLength list", argument: Argument{Type: "Int64[]", MaxLength: 2}, want: []int64(nil)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.argument
+			if err := a.SetValue("1,2,3"); err == nil {
+				t.Fatal("Argument.SetValue() error = nil")
+			}
+			if !reflect.DeepEqual(a.Value, tt.want) {
+				t.Errorf("Argument.Value = %#v, want %#v", a.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgument_constraintDescriptions(t *testing.T) {
+	a := &Argument{Type: "ByteSize", Min: "1k", MinLength: 0, MaxLength: 0}
+	if got := a.constraintDescriptions(); !reflect.DeepEqual(got, []string{"range: 1 kB.."}) {
+		t.Errorf("Argument.constraintDescriptions() = %v", got)
+	}
+
+	a = &Argument{Type: "String", MinLength: 2, MaxLength: 8, Pattern: "^[a-z]+$"}
+	want := []string{"length: 2..8", "pattern: ^[a-z]+$"}
+	if got := a.constraintDescriptions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Argument.constraintDescriptions() = %v, want %v", got, want)
+	}
+}
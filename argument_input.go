@@ -0,0 +1,134 @@
+package commander
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// StdinName is the value that means the standard input
+const StdinName = "-"
+
+// Input is the value of Input arguments: a file or the standard input.
+// The file is opened on the first Read.
+type Input struct {
+	// Name is the path of the file or "-" for the standard input
+	Name string
+
+	reader io.Reader
+}
+
+// IsStdin reports whether the input is the standard input
+func (i *Input) IsStdin() bool {
+	return i.Name == StdinName
+}
+
+// Read reads from the file or from the standard input,
+// the file is opened on the first call
+func (i *Input) Read(p []byte) (int, error) {
+	if i.reader == nil && i.Name != "" && !i.IsStdin() {
+		file, err := os.Open(i.Name)
+		if err != nil {
+			return 0, &PathError{Path: i.Name, Reason: "cannot open", Err: err}
+		}
+		i.reader = file
+	}
+
+	if i.reader == nil {
+		return 0, errors.New("input is not opened")
+	}
+
+	return i.reader.Read(p)
+}
+
+// Close closes the file, the standard input is not closed
+func (i *Input) Close() error {
+	if closer, ok := i.reader.(io.Closer); ok && !i.IsStdin() {
+		return closer.Close()
+	}
+
+	return nil
+}
+
+// stdinInput is the value of Input arguments until
+// the standard input is attached by CommandHelper.Parse
+type stdinInput struct{}
+
+func (s stdinInput) resolveStdin(stdin io.Reader) (interface{}, error) {
+	return &Input{Name: StdinName, reader: stdin}, nil
+}
+
+// stdinContent is the value of Content arguments until
+// the standard input is read by CommandHelper.Parse
+type stdinContent struct{}
+
+func (s stdinContent) resolveStdin(stdin io.Reader) (interface{}, error) {
+	content, err := ioutil.ReadAll(stdin)
+	if err != nil {
+		return "", err
+	}
+
+	return string(content), nil
+}
+
+// stdinResolver is implemented by argument values
+// that need the standard input of the CommandHelper
+type stdinResolver interface {
+	resolveStdin(stdin io.Reader) (interface{}, error)
+}
+
+func init() {
+	// Input is a file path or "-" for the standard input. Any existing
+	// path except directories is accepted: /dev/stdin, FIFOs, <(cmd)
+	registerBuiltinFileType("Input", func(value string) (interface{}, error) {
+		if value == StdinName {
+			return stdinInput{}, nil
+		}
+
+		path, err := checkPath(value, PathMustExist)
+		if err != nil {
+			return &Input{}, err
+		}
+
+		if info, err := os.Stat(path); err == nil && info.IsDir() {
+			return &Input{}, &PathError{Path: path, Reason: "is a directory"}
+		}
+
+		return &Input{Name: path}, nil
+	})
+
+	// Content is a literal value, "@file" reads the value from a file,
+	// "-" or "@-" reads it from the standard input, "@@" is a literal "@"
+	registerBuiltinFileType("Content", func(value string) (interface{}, error) {
+		switch {
+		case value == StdinName || value == "@"+StdinName:
+			return stdinContent{}, nil
+		case strings.HasPrefix(value, "@@"):
+			return value[1:], nil
+		case !strings.HasPrefix(value, "@"):
+			return value, nil
+		}
+
+		return readContent(value[1:])
+	})
+}
+
+// readContent returns with the content of the file,
+// the home directory is expanded (~/file)
+func readContent(path string) (string, error) {
+	expanded, err := homedir.Expand(path)
+	if err != nil {
+		return "", &PathError{Path: path, Reason: "cannot expand home directory", Err: err}
+	}
+
+	content, err := ioutil.ReadFile(expanded)
+	if err != nil {
+		return "", &PathError{Path: expanded, Reason: "cannot read", Err: err}
+	}
+
+	return string(content), nil
+}
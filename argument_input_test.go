@@ -0,0 +1,166 @@
+package commander
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestArgument_SetValue_content(t *testing.T) {
+	directory, err := ioutil.TempDir("", "commander")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(directory)
+
+	secretFile := filepath.Join(directory, "secret.txt")
+	ioutil.WriteFile(secretFile, []byte("s3cr3t"), 0600)
+
+	tests := []struct {
+		name      string
+		parameter string
+		want      interface{}
+		wantErr   bool
+	}{
+		{name: "Literal", parameter: "value", want: "value"},
+		{name: "File", parameter: "@" + secretFile, want: "s3cr3t"},
+		{name: "Escaped at sign", parameter: "@@value", want: "@value"},
+		{name: "Missing file", parameter: "@" + secretFile + ".missing", want: "", wantErr: true},
+		{name: "Stdin", parameter: "-", want: stdinContent{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: "Content"}
+			if err := a.SetValue(tt.parameter); (err != nil) != tt.wantErr {
+				t.Errorf("Argument.SetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if a.Value != tt.want {
+				t.Errorf("Argument.Value = %v, want %v", a.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandHelper_Parse_stdin(t *testing.T) {
+	tests := []struct {
+		name    string
+		flag    []string
+		want    map[string]string
+		wantErr map[string]string
+	}{
+		{
+			name: "Input from stdin",
+			flag: []string{"command", "--input=-"},
+			want: map[string]string{"input": "from stdin"},
+		},
+		{
+			name: "Input from file",
+			flag: []string{"command", "--input=argument_input_test.go"},
+			want: map[string]string{"input": "package commander"},
+		},
+		{
+			name:    "Input file does not exist",
+			flag:    []string{"command", "--input=not-exists.txt"},
+			wantErr: map[string]string{"input": "not-exists.txt: does not exist"},
+		},
+		{
+			name:    "Input is a directory",
+			flag:    []string{"command", "--input=."},
+			wantErr: map[string]string{"input": ".: is a directory"},
+		},
+		{
+			name: "Content from stdin",
+			flag: []string{"command", "--body=-"},
+			want: map[string]string{"body": "from stdin"},
+		},
+		{
+			name:    "Stdin used twice",
+			flag:    []string{"command", "--input=-", "--body=-"},
+			want:    map[string]string{"input": "from stdin"},
+			wantErr: map[string]string{"body": "standard input is already used by another argument"},
+		},
+	}
+
+	oldOutput := FmtPrintf
+	defer func() { FmtPrintf = oldOutput }()
+	FmtPrintf = mockPrintf
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CommandHelper{Stdin: strings.NewReader("from stdin")}
+			c.AttachArgumentList([]*Argument{
+				&Argument{Name: "input", Type: "Input"},
+				&Argument{Name: "body", Type: "Content"},
+			})
+			c.Parse(tt.flag)
+
+			for key, want := range tt.want {
+				var got string
+				switch value := c.TypedOpt(key).(type) {
+				case *Input:
+					content, _ := ioutil.ReadAll(value)
+					value.Close()
+					got = string(content)
+				case string:
+					got = value
+				}
+
+				if !strings.HasPrefix(got, want) {
+					t.Errorf("CommandHelper.TypedOpt(%s) = %q, want %q", key, got, want)
+				}
+			}
+
+			for key, want := range tt.wantErr {
+				if err := c.ErrorForTypedOpt(key); err == nil || err.Error() != want {
+					t.Errorf("CommandHelper.ErrorForTypedOpt(%s) = %v, want %s", key, err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestInput_lazyOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "input.txt")
+	ioutil.WriteFile(path, []byte("content"), 0600)
+
+	a := &Argument{Type: "Input"}
+	if err := a.SetValue(path); err != nil {
+		t.Fatal(err)
+	}
+
+	input := a.Value.(*Input)
+	if input.reader != nil {
+		t.Error("Input should not be opened before the first Read")
+	}
+
+	os.Remove(path)
+	if _, err := ioutil.ReadAll(input); err == nil || !strings.Contains(err.Error(), "cannot open") {
+		t.Errorf("Input.Read() error = %v, want cannot open", err)
+	}
+}
+
+func TestCommandHelper_Parse_inputList(t *testing.T) {
+	mockEverything()
+
+	c := &CommandHelper{Stdin: strings.NewReader("from stdin")}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "input", Type: "Input[]"},
+	})
+	c.Parse([]string{"command", "--input=argument_input_test.go,-"})
+
+	inputs, ok := c.TypedOpt("input").([]*Input)
+	if !ok || len(inputs) != 2 {
+		t.Fatalf("CommandHelper.TypedOpt(input) = %#v, want []*Input", c.TypedOpt("input"))
+	}
+
+	if content, _ := ioutil.ReadAll(inputs[1]); !inputs[1].IsStdin() || string(content) != "from stdin" {
+		t.Errorf("second input = %q, want standard input", content)
+	}
+
+	c.Parse([]string{"command", "--input=-,-"})
+	if err := c.ErrorForTypedOpt("input"); err == nil || err.Error() != "item 2 (-): standard input is already used by another argument" {
+		t.Errorf("CommandHelper.ErrorForTypedOpt(input) = %v", err)
+	}
+}
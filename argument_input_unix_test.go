@@ -0,0 +1,24 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package commander
+
+import (
+	"path/filepath"
+	"syscall"
+	"testing"
+)
+
+func TestArgument_SetValue_inputFIFO(t *testing.T) {
+	fifo := filepath.Join(t.TempDir(), "fifo")
+	if err := syscall.Mkfifo(fifo, 0600); err != nil {
+		t.Skip("mkfifo is not supported:", err)
+	}
+
+	for _, path := range []string{fifo, "/dev/stdin"} {
+		a := &Argument{Type: "Input"}
+		if err := a.SetValue(path); err != nil {
+			t.Errorf("Argument.SetValue(%s) error = %v, want nil", path, err)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package commander
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+)
+
+// RegisterJSONArgumentType registers a JSON type shared by all
+// CommandRegistry, see TypeRegistry.RegisterJSON
+func RegisterJSONArgumentType(name string, target interface{}) error {
+	return sharedTypes.RegisterJSON(name, target)
+}
+
+// RegisterJSON registers a JSON type that decodes into
+// a new value with the type of target. The value of the argument
+// is a pointer to the decoded value:
+//
+//	types.RegisterJSON("Body", RequestBody{}) // *RequestBody
+func (r *TypeRegistry) RegisterJSON(name string, target interface{}) error {
+	targetType := reflect.TypeOf(target)
+	if targetType == nil {
+		return fmt.Errorf("JSON argument type %s: target is nil, use the JSON type for any value", name)
+	}
+
+	if targetType.Kind() == reflect.Ptr {
+		targetType = targetType.Elem()
+	}
+
+	return r.Register(name, func(value string) (interface{}, error) {
+		decoded := reflect.New(targetType)
+		if err := decodeJSON(value, decoded.Interface()); err != nil {
+			return reflect.New(targetType).Interface(), err
+		}
+
+		return decoded.Interface(), nil
+	})
+}
+
+// decodeJSON decodes value into target,
+// errors contain the byte offset of the problem
+func decodeJSON(value string, target interface{}) error {
+	err := json.Unmarshal([]byte(value), target)
+	switch e := err.(type) {
+	case nil:
+		return nil
+	case *json.SyntaxError:
+		return fmt.Errorf("invalid JSON at byte %d: %s", e.Offset, e)
+	case *json.UnmarshalTypeError:
+		if e.Field == "" {
+			return fmt.Errorf("invalid JSON at byte %d: cannot use %s as %s", e.Offset, e.Value, e.Type)
+		}
+
+		return fmt.Errorf("invalid JSON at byte %d: cannot use %s as %s in %s", e.Offset, e.Value, e.Type, e.Field)
+	}
+
+	return fmt.Errorf("invalid JSON: %s", err)
+}
+
+func init() {
+	registerBuiltinType("JSON", func(value string) (interface{}, error) {
+		var decoded interface{}
+		if err := decodeJSON(value, &decoded); err != nil {
+			return nil, err
+		}
+
+		return decoded, nil
+	})
+}
@@ -0,0 +1,54 @@
+package commander
+
+import (
+	"reflect"
+	"testing"
+)
+
+type myRequestBody struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestArgument_SetValue_json(t *testing.T) {
+	RegisterJSONArgumentType("RequestBody", &myRequestBody{})
+
+	tests := []struct {
+		name      string
+		argType   string
+		parameter string
+		want      interface{}
+		wantErr   string
+	}{
+		{name: "Object", argType: "JSON", parameter: `{"a":1}`, want: map[string]interface{}{"a": float64(1)}},
+		{name: "Array", argType: "JSON", parameter: `[1, "two"]`, want: []interface{}{float64(1), "two"}},
+		{name: "Syntax error", argType: "JSON", parameter: `{"a":1,}`, want: nil, wantErr: "invalid JSON at byte 8: invalid character '}' looking for beginning of object key string"},
+		{name: "Trailing data", argType: "JSON", parameter: `{"a":1} x`, want: nil, wantErr: "invalid JSON at byte 9: invalid character 'x' after top-level value"},
+		{name: "Empty", argType: "JSON", parameter: ``, want: nil, wantErr: "invalid JSON at byte 0: unexpected end of JSON input"},
+		{name: "Target type", argType: "RequestBody", parameter: `{"name":"x","count":3}`, want: &myRequestBody{Name: "x", Count: 3}},
+		{name: "Target type mismatch [top-level]", argType: "RequestBody", parameter: `[1]`, want: &myRequestBody{}, wantErr: "invalid JSON at byte 1: cannot use array as commander.myRequestBody"},
+		{name: "Target type mismatch", argType: "RequestBody", parameter: `{"name":"x","count":"3"}`, want: &myRequestBody{}, wantErr: "invalid JSON at byte 23: cannot use string as int in count"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: tt.argType}
+			err := a.SetValue(tt.parameter)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Argument.SetValue() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(a.Value, tt.want) {
+				t.Errorf("Argument.Value = %#v, want %#v", a.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterJSONArgumentType_nil(t *testing.T) {
+	if err := RegisterJSONArgumentType("NilJSON", nil); err == nil {
+		t.Error("RegisterJSONArgumentType(nil) should fail")
+	}
+
+	if sharedTypes.Has("NilJSON") {
+		t.Error("NilJSON should not be registered")
+	}
+}
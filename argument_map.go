@@ -0,0 +1,130 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errLineBreak is returned for line breaks outside of quotes
+var errLineBreak = errors.New("unexpected line break, quote values containing line breaks")
+
+// splitCSV splits a comma separated value following CSV quoting rules:
+// a field in double quotes can contain commas and line breaks, "" is
+// an escaped quote. Unquoted fields are trimmed, quoted fields are kept
+// as they are. Unquoted line breaks are rejected.
+func splitCSV(value string) ([]string, error) {
+	value = strings.TrimRight(value, "\r\n")
+	fields := []string{}
+
+	for position := 0; ; position++ {
+		field, end, err := readCSVField(value, position)
+		if err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field)
+		if end >= len(value) {
+			return fields, nil
+		}
+
+		position = end
+	}
+}
+
+// readCSVField reads the field starting at start,
+// end is the position of the comma after the field
+func readCSVField(value string, start int) (field string, end int, err error) {
+	position := skipBlanks(value, start)
+	if position >= len(value) || value[position] != '"' {
+		end = strings.IndexByte(value[start:], ',')
+		if end < 0 {
+			end = len(value)
+		} else {
+			end += start
+		}
+
+		field = value[start:end]
+		if strings.ContainsAny(field, "\r\n") {
+			return "", 0, errLineBreak
+		}
+
+		if strings.Contains(field, `"`) {
+			return "", 0, fmt.Errorf("invalid quoting: unexpected quote in %q, quote the whole value", strings.TrimSpace(field))
+		}
+
+		return strings.TrimSpace(field), end, nil
+	}
+
+	var builder strings.Builder
+	for position++; position < len(value); position++ {
+		if value[position] != '"' {
+			builder.WriteByte(value[position])
+			continue
+		}
+
+		if position+1 < len(value) && value[position+1] == '"' {
+			builder.WriteByte('"')
+			position++
+			continue
+		}
+
+		// only blanks are allowed between the closing quote and the comma
+		end = skipBlanks(value, position+1)
+		switch {
+		case end >= len(value) || value[end] == ',':
+			return builder.String(), end, nil
+		case value[end] == '\r' || value[end] == '\n':
+			return "", 0, errLineBreak
+		}
+
+		return "", 0, fmt.Errorf("invalid quoting: unexpected %q after the closing quote", value[end])
+	}
+
+	return "", 0, errors.New("invalid quoting: missing closing quote")
+}
+
+// skipBlanks returns with the position of the first
+// character from position that is not a space or a tab
+func skipBlanks(value string, position int) int {
+	for position < len(value) && (value[position] == ' ' || value[position] == '\t') {
+		position++
+	}
+
+	return position
+}
+
+// parseStringMap parses key=value pairs separated by commas.
+// Keys and values are trimmed, values can contain equal signs,
+// pairs with commas must be quoted:
+// env=prod,"desc=one, two",query=a=b
+func parseStringMap(value string) (map[string]string, error) {
+	result := map[string]string{}
+
+	pairs, err := splitCSV(value)
+	if err != nil {
+		return result, err
+	}
+
+	for _, pair := range pairs {
+		parts := strings.SplitN(pair, "=", 2)
+		if len(parts) < 2 {
+			return map[string]string{}, fmt.Errorf("malformed pair %q, expected key=value", pair)
+		}
+
+		key := strings.TrimSpace(parts[0])
+		if key == "" {
+			return map[string]string{}, fmt.Errorf("malformed pair %q, key is empty", pair)
+		}
+
+		result[key] = strings.TrimSpace(parts[1])
+	}
+
+	return result, nil
+}
+
+func init() {
+	registerBuiltinListType("StringMap", func(value string) (interface{}, error) {
+		return parseStringMap(value)
+	})
+}
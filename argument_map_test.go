@@ -0,0 +1,79 @@
+package commander
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArgument_SetValue_stringMap(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter string
+		want      map[string]string
+		wantErr   string
+	}{
+		{name: "Simple", parameter: "env=prod,team=core", want: map[string]string{"env": "prod", "team": "core"}},
+		{name: "Equal sign in value", parameter: "query=a=b", want: map[string]string{"query": "a=b"}},
+		{name: "Quoted comma", parameter: `env=prod,"desc=one, two"`, want: map[string]string{"env": "prod", "desc": "one, two"}},
+		{name: "Escaped quote", parameter: `"desc=say ""hi"""`, want: map[string]string{"desc": `say "hi"`}},
+		{name: "Spaces", parameter: "a=1, b = 2 ", want: map[string]string{"a": "1", "b": "2"}},
+		{name: "Quoted spaces", parameter: `" c = 3 "`, want: map[string]string{"c": "3"}},
+		{name: "Empty value", parameter: "env=", want: map[string]string{"env": ""}},
+		{name: "Missing equal sign", parameter: "env=prod,team", want: map[string]string{}, wantErr: `malformed pair "team", expected key=value`},
+		{name: "Empty key", parameter: "=prod", want: map[string]string{}, wantErr: `malformed pair "=prod", key is empty`},
+		{name: "Line break", parameter: "a=1\nb=2", want: map[string]string{}, wantErr: "unexpected line break, quote values containing line breaks"},
+		{name: "Quoted line break", parameter: "\"desc=one\ntwo\"", want: map[string]string{"desc": "one\ntwo"}},
+		{name: "Trailing line break", parameter: "a=1\n", want: map[string]string{"a": "1"}},
+		{name: "Unterminated quote", parameter: `"env=prod`, want: map[string]string{}, wantErr: "invalid quoting: missing closing quote"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: "StringMap"}
+			err := a.SetValue(tt.parameter)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Argument.SetValue() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(a.Value, tt.want) {
+				t.Errorf("Argument.Value = %v, want %v", a.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandHelper_Parse_repeated(t *testing.T) {
+	c := &CommandHelper{}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "set", Type: "StringMap"},
+		&Argument{Name: "label", Type: "StringMap"},
+		&Argument{Name: "name", Type: "String"},
+	})
+	c.Parse([]string{
+		"command",
+		"--set", "a=1", "--set=b=2",
+		"--label=env=prod,team=core",
+		"--name=first", "--name", "second",
+		"argument",
+	})
+
+	want := map[string]string{"a": "1", "b": "2"}
+	if got := c.TypedOpt("set"); !reflect.DeepEqual(got, want) {
+		t.Errorf("CommandHelper.TypedOpt(set) = %v, want %v", got, want)
+	}
+
+	want = map[string]string{"env": "prod", "team": "core"}
+	if got := c.TypedOpt("label"); !reflect.DeepEqual(got, want) {
+		t.Errorf("CommandHelper.TypedOpt(label) = %v, want %v", got, want)
+	}
+
+	if got := c.TypedOpt("name"); got != "second" {
+		t.Errorf("CommandHelper.TypedOpt(name) = %v, want second", got)
+	}
+
+	if got := c.OptList("name"); !reflect.DeepEqual(got, []string{"first", "second"}) {
+		t.Errorf("CommandHelper.OptList(name) = %v, want [first second]", got)
+	}
+
+	if got := c.Arg(0); got != "argument" {
+		t.Errorf("CommandHelper.Arg(0) = %v, want argument", got)
+	}
+}
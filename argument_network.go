@@ -0,0 +1,97 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// HostPort is the value of HostPort arguments
+type HostPort struct {
+	Host string
+	Port uint16
+}
+
+// String returns with host:port, IPv6 hosts are in brackets
+func (h HostPort) String() string {
+	return net.JoinHostPort(h.Host, strconv.FormatUint(uint64(h.Port), 10))
+}
+
+// RegisterURLArgumentType registers a URL type shared by all
+// CommandRegistry, see TypeRegistry.RegisterURL
+func RegisterURLArgumentType(name string, schemes ...string) error {
+	return sharedTypes.RegisterURL(name, schemes...)
+}
+
+// RegisterURL registers a URL type that accepts only the
+// given schemes, any scheme is accepted if schemes is empty
+func (r *TypeRegistry) RegisterURL(name string, schemes ...string) error {
+	return r.Register(name, urlArgumentType(schemes))
+}
+
+func urlArgumentType(schemes []string) argumentTypeFunction {
+	return func(value string) (interface{}, error) {
+		return parseURL(value, schemes)
+	}
+}
+
+func parseURL(value string, schemes []string) (*url.URL, error) {
+	u, err := url.Parse(value)
+	// localhost:8080 is parsed as scheme "localhost"
+	if err != nil || u.Scheme == "" || (u.Host == "" && u.Opaque != "") {
+		return &url.URL{}, errors.New("expected an absolute URL like https://example.com/path")
+	}
+
+	if len(schemes) < 1 {
+		return u, nil
+	}
+
+	for _, scheme := range schemes {
+		if strings.EqualFold(u.Scheme, scheme) {
+			return u, nil
+		}
+	}
+
+	return &url.URL{}, fmt.Errorf("unsupported URL scheme %q, expected one of: %s", u.Scheme, strings.Join(schemes, ", "))
+}
+
+func init() {
+	registerBuiltinType("URL", urlArgumentType(nil))
+
+	registerBuiltinType("IP", func(value string) (interface{}, error) {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return net.IP{}, errors.New("expected an IPv4 or IPv6 address like 192.168.0.1 or 2001:db8::1")
+		}
+
+		return ip, nil
+	})
+
+	registerBuiltinType("CIDR", func(value string) (interface{}, error) {
+		_, network, err := net.ParseCIDR(value)
+		if err != nil {
+			return &net.IPNet{}, errors.New("expected a CIDR notation like 192.168.0.0/24 or 2001:db8::/32")
+		}
+
+		return network, nil
+	})
+
+	registerBuiltinType("HostPort", func(value string) (interface{}, error) {
+		formatError := errors.New("expected host:port like example.com:443 or [2001:db8::1]:80")
+
+		host, portValue, err := net.SplitHostPort(value)
+		if err != nil || host == "" {
+			return HostPort{}, formatError
+		}
+
+		port, err := strconv.ParseUint(portValue, 10, 16)
+		if err != nil {
+			return HostPort{}, formatError
+		}
+
+		return HostPort{Host: host, Port: uint16(port)}, nil
+	})
+}
@@ -0,0 +1,55 @@
+package commander
+
+import (
+	"net"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+func TestArgument_SetValue_network(t *testing.T) {
+	RegisterURLArgumentType("HTTPURL", "http", "https")
+
+	_, network, _ := net.ParseCIDR("10.0.0.0/8")
+
+	tests := []struct {
+		name      string
+		argType   string
+		parameter string
+		want      interface{}
+		wantErr   string
+	}{
+		{name: "URL", argType: "URL", parameter: "ftp://example.com/file", want: &url.URL{Scheme: "ftp", Host: "example.com", Path: "/file"}},
+		{name: "URL [relative]", argType: "URL", parameter: "example.com/file", want: &url.URL{}, wantErr: "expected an absolute URL like https://example.com/path"},
+		{name: "URL [host:port]", argType: "URL", parameter: "localhost:8080", want: &url.URL{}, wantErr: "expected an absolute URL like https://example.com/path"},
+		{name: "HTTPURL", argType: "HTTPURL", parameter: "HTTPS://example.com", want: &url.URL{Scheme: "https", Host: "example.com"}},
+		{name: "HTTPURL [scheme]", argType: "HTTPURL", parameter: "ftp://example.com", want: &url.URL{}, wantErr: `unsupported URL scheme "ftp", expected one of: http, https`},
+		{name: "IP", argType: "IP", parameter: "192.168.0.1", want: net.ParseIP("192.168.0.1")},
+		{name: "IP [v6]", argType: "IP", parameter: "2001:db8::1", want: net.ParseIP("2001:db8::1")},
+		{name: "IP [invalid]", argType: "IP", parameter: "192.168.0", want: net.IP{}, wantErr: "expected an IPv4 or IPv6 address like 192.168.0.1 or 2001:db8::1"},
+		{name: "CIDR", argType: "CIDR", parameter: "10.1.2.3/8", want: network},
+		{name: "CIDR [invalid]", argType: "CIDR", parameter: "10.1.2.3", want: &net.IPNet{}, wantErr: "expected a CIDR notation like 192.168.0.0/24 or 2001:db8::/32"},
+		{name: "HostPort", argType: "HostPort", parameter: "example.com:443", want: HostPort{Host: "example.com", Port: 443}},
+		{name: "HostPort [v6]", argType: "HostPort", parameter: "[::1]:80", want: HostPort{Host: "::1", Port: 80}},
+		{name: "HostPort [no port]", argType: "HostPort", parameter: "example.com", want: HostPort{}, wantErr: "expected host:port like example.com:443 or [2001:db8::1]:80"},
+		{name: "HostPort [invalid port]", argType: "HostPort", parameter: "example.com:70000", want: HostPort{}, wantErr: "expected host:port like example.com:443 or [2001:db8::1]:80"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: tt.argType}
+			err := a.SetValue(tt.parameter)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Argument.SetValue() error = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(a.Value, tt.want) {
+				t.Errorf("Argument.Value = %#v, want %#v", a.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestHostPort_String(t *testing.T) {
+	if got := (HostPort{Host: "::1", Port: 80}).String(); got != "[::1]:80" {
+		t.Errorf("HostPort.String() = %s, want [::1]:80", got)
+	}
+}
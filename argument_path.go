@@ -0,0 +1,110 @@
+package commander
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/mitchellh/go-homedir"
+)
+
+// PathMode defines the checks of a path argument type
+type PathMode int
+
+const (
+	// PathMustExist rejects paths that do not exist
+	PathMustExist PathMode = 1 << iota
+	// PathMustNotExist rejects paths that already exist (output files)
+	PathMustNotExist
+	// PathDirectory rejects existing paths that are not directories
+	PathDirectory
+	// PathRegularFile rejects existing paths that are not regular files
+	PathRegularFile
+	// PathAbsolute converts the path to an absolute path
+	PathAbsolute
+)
+
+// PathError is the error of path argument types
+type PathError struct {
+	// Path is the expanded path
+	Path string
+	// Reason describes what's wrong with the path
+	Reason string
+	// Err is the underlying error if any
+	Err error
+}
+
+func (e *PathError) Error() string {
+	if e.Err != nil {
+		return e.Path + ": " + e.Reason + " (" + e.Err.Error() + ")"
+	}
+
+	return e.Path + ": " + e.Reason
+}
+
+// Unwrap returns with the underlying error
+func (e *PathError) Unwrap() error {
+	return e.Err
+}
+
+// RegisterPathArgumentType registers a path type shared by all
+// CommandRegistry, see TypeRegistry.RegisterPath
+func RegisterPathArgumentType(name string, mode PathMode) error {
+	return sharedTypes.RegisterPath(name, mode)
+}
+
+// RegisterPath registers a path type with the given checks.
+// Paths are expanded (~/file), the value is an empty string on error.
+func (r *TypeRegistry) RegisterPath(name string, mode PathMode) error {
+	return r.add(name, typeEntry{convert: pathArgumentType(mode), sideEffects: true})
+}
+
+func pathArgumentType(mode PathMode) argumentTypeFunction {
+	return func(value string) (interface{}, error) {
+		path, err := checkPath(value, mode)
+		if err != nil {
+			return "", err
+		}
+
+		return path, nil
+	}
+}
+
+func checkPath(value string, mode PathMode) (string, error) {
+	path, err := homedir.Expand(value)
+	if err != nil {
+		return "", &PathError{Path: value, Reason: "cannot expand home directory", Err: err}
+	}
+
+	if mode&PathAbsolute != 0 {
+		if path, err = filepath.Abs(path); err != nil {
+			return "", &PathError{Path: value, Reason: "cannot resolve absolute path", Err: err}
+		}
+	}
+
+	info, err := os.Stat(path)
+	if err != nil && !os.IsNotExist(err) {
+		return "", &PathError{Path: path, Reason: "cannot access", Err: err}
+	}
+
+	exists := err == nil
+	switch {
+	case !exists && mode&PathMustExist != 0:
+		return "", &PathError{Path: path, Reason: "does not exist"}
+	case exists && mode&PathMustNotExist != 0:
+		return "", &PathError{Path: path, Reason: "already exists"}
+	case exists && mode&PathDirectory != 0 && !info.IsDir():
+		return "", &PathError{Path: path, Reason: "is not a directory"}
+	case exists && mode&PathRegularFile != 0 && !info.Mode().IsRegular():
+		return "", &PathError{Path: path, Reason: "is not a regular file"}
+	}
+
+	return path, nil
+}
+
+func init() {
+	registerBuiltinFileType("FilePath", pathArgumentType(PathMustExist))
+	registerBuiltinFileType("RegularFile", pathArgumentType(PathMustExist|PathRegularFile))
+	registerBuiltinFileType("Directory", pathArgumentType(PathMustExist|PathDirectory))
+	registerBuiltinFileType("NewFilePath", pathArgumentType(PathMustNotExist))
+	registerBuiltinFileType("AbsPath", pathArgumentType(PathAbsolute))
+}
@@ -0,0 +1,53 @@
+package commander
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestArgument_SetValue_path(t *testing.T) {
+	workingDirectory, _ := os.Getwd()
+
+	tests := []struct {
+		name      string
+		argType   string
+		parameter string
+		want      string
+		wantErr   string
+	}{
+		{name: "FilePath [directory]", argType: "FilePath", parameter: ".", want: "."},
+		{name: "FilePath [not exists]", argType: "FilePath", parameter: "/ajshdjkashdjashdjasd", want: "", wantErr: "/ajshdjkashdjashdjasd: does not exist"},
+		{name: "RegularFile", argType: "RegularFile", parameter: "argument_test.go", want: "argument_test.go"},
+		{name: "RegularFile [directory]", argType: "RegularFile", parameter: ".", want: "", wantErr: ".: is not a regular file"},
+		{name: "Directory", argType: "Directory", parameter: ".", want: "."},
+		{name: "Directory [file]", argType: "Directory", parameter: "argument_test.go", want: "", wantErr: "argument_test.go: is not a directory"},
+		{name: "NewFilePath", argType: "NewFilePath", parameter: "/ajshdjkashdjashdjasd", want: "/ajshdjkashdjashdjasd"},
+		{name: "NewFilePath [exists]", argType: "NewFilePath", parameter: "argument_test.go", want: "", wantErr: "argument_test.go: already exists"},
+		{name: "AbsPath", argType: "AbsPath", parameter: "not-yet-created.txt", want: filepath.Join(workingDirectory, "not-yet-created.txt")},
+		{name: "Invalid home", argType: "FilePath", parameter: "~someone/file", want: "", wantErr: "~someone/file: cannot expand home directory (cannot expand user-specific home dir)"},
+	}
+
+	oldOutput := FmtPrintf
+	defer func() { FmtPrintf = oldOutput }()
+	FmtPrintf = func(format string, n ...interface{}) (int, error) {
+		t.Errorf("unexpected output: "+format, n...)
+		return 0, nil
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: tt.argType}
+			err := a.SetValue(tt.parameter)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("Argument.SetValue() error = %v, want %v", err, tt.wantErr)
+			}
+			if _, ok := err.(*PathError); err != nil && !ok {
+				t.Errorf("Argument.SetValue() error is %T, want *PathError", err)
+			}
+			if a.Value != tt.want {
+				t.Errorf("Argument.Value = %v, want %v", a.Value, tt.want)
+			}
+		})
+	}
+}
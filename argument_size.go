@@ -0,0 +1,120 @@
+package commander
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ByteSize is the value of ByteSize arguments in bytes
+type ByteSize uint64
+
+// Percent is the value of Percent arguments, 75% is Percent(75)
+type Percent float64
+
+type byteUnit struct {
+	name string
+	size float64
+}
+
+// byteUnits are ordered by size, SI unit before IEC unit in the same magnitude
+var byteUnits = []byteUnit{
+	{"EB", 1e18}, {"EiB", 1 << 60},
+	{"PB", 1e15}, {"PiB", 1 << 50},
+	{"TB", 1e12}, {"TiB", 1 << 40},
+	{"GB", 1e9}, {"GiB", 1 << 30},
+	{"MB", 1e6}, {"MiB", 1 << 20},
+	{"kB", 1e3}, {"KiB", 1 << 10},
+	{"B", 1},
+}
+
+// String returns with a human-readable form: 10 MB, 1.5 GiB
+func (s ByteSize) String() string {
+	value := float64(s)
+	if value == 0 {
+		return "0 B"
+	}
+
+	// whole numbers are easier to read: 512 kB instead of 500 KiB
+	for _, unit := range byteUnits {
+		quotient := value / unit.size
+		if quotient >= 1 && quotient < 1024 && quotient == math.Trunc(quotient) {
+			return strconv.FormatFloat(quotient, 'f', -1, 64) + " " + unit.name
+		}
+	}
+
+	for _, unit := range byteUnits {
+		quotient := value / unit.size
+		if quotient >= 1 && quotient*100 == math.Trunc(quotient*100) {
+			return strconv.FormatFloat(quotient, 'f', -1, 64) + " " + unit.name
+		}
+	}
+
+	return strconv.FormatUint(uint64(s), 10) + " B"
+}
+
+// String returns with the percentage: 75%
+func (p Percent) String() string {
+	return strconv.FormatFloat(float64(p), 'f', -1, 64) + "%"
+}
+
+// Fraction returns with the percentage as a fraction: 75% is 0.75
+func (p Percent) Fraction() float64 {
+	return float64(p) / 100
+}
+
+// parseByteSize parses sizes with SI (k, MB) and IEC (Ki, MiB) units,
+// units are case-insensitive and the B suffix is optional
+func parseByteSize(value string) (ByteSize, error) {
+	formatError := errors.New("expected a size like 512k, 10MB or 1.5GiB")
+
+	value = strings.TrimSpace(value)
+	split := strings.IndexFunc(value, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if split < 0 {
+		split = len(value)
+	}
+
+	number, err := strconv.ParseFloat(value[:split], 64)
+	if err != nil || number < 0 {
+		return 0, formatError
+	}
+
+	unit := strings.ToLower(strings.TrimSpace(value[split:]))
+	multiplier := float64(0)
+	for _, u := range byteUnits {
+		name := strings.ToLower(u.name)
+		if unit == name || unit == strings.TrimSuffix(name, "b") {
+			multiplier = u.size
+			break
+		}
+	}
+
+	if multiplier == 0 {
+		return 0, formatError
+	}
+
+	size := math.Round(number * multiplier)
+	if size >= math.MaxUint64 {
+		return 0, errors.New("size is too large")
+	}
+
+	return ByteSize(size), nil
+}
+
+func init() {
+	registerBuiltinType("ByteSize", func(value string) (interface{}, error) {
+		return parseByteSize(value)
+	})
+
+	registerBuiltinType("Percent", func(value string) (interface{}, error) {
+		number, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(value), "%"), 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return Percent(0), errors.New("expected a percentage like 75% or 12.5%")
+		}
+
+		return Percent(number), nil
+	})
+}
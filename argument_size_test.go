@@ -0,0 +1,74 @@
+package commander
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestArgument_SetValue_size(t *testing.T) {
+	tests := []struct {
+		name      string
+		argType   string
+		parameter string
+		want      interface{}
+		wantErr   bool
+	}{
+		{name: "Bytes", argType: "ByteSize", parameter: "512", want: ByteSize(512)},
+		{name: "Bytes with unit", argType: "ByteSize", parameter: "512B", want: ByteSize(512)},
+		{name: "SI short", argType: "ByteSize", parameter: "512k", want: ByteSize(512000)},
+		{name: "SI", argType: "ByteSize", parameter: "10MB", want: ByteSize(10000000)},
+		{name: "IEC", argType: "ByteSize", parameter: "1.5GiB", want: ByteSize(1610612736)},
+		{name: "IEC short lowercase", argType: "ByteSize", parameter: "2 ki", want: ByteSize(2048)},
+		{name: "Unknown unit", argType: "ByteSize", parameter: "10XB", want: ByteSize(0), wantErr: true},
+		{name: "Double unit", argType: "ByteSize", parameter: "10bb", want: ByteSize(0), wantErr: true},
+		{name: "Negative", argType: "ByteSize", parameter: "-1k", want: ByteSize(0), wantErr: true},
+		{name: "Too large", argType: "ByteSize", parameter: "20EiB", want: ByteSize(0), wantErr: true},
+		{name: "Percent", argType: "Percent", parameter: "75%", want: Percent(75)},
+		{name: "Percent without sign", argType: "Percent", parameter: "12.5", want: Percent(12.5)},
+		{name: "Percent invalid", argType: "Percent", parameter: "half", want: Percent(0), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: tt.argType}
+			if err := a.SetValue(tt.parameter); (err != nil) != tt.wantErr {
+				t.Errorf("Argument.SetValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(a.Value, tt.want) {
+				t.Errorf("Argument.Value = %v, want %v", a.Value, tt.want)
+			}
+		})
+	}
+}
+
+func TestByteSize_String(t *testing.T) {
+	tests := []struct {
+		size ByteSize
+		want string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{512000, "512 kB"},
+		{1024, "1 KiB"},
+		{1536, "1.5 KiB"},
+		{10000000, "10 MB"},
+		{1610612736, "1.5 GiB"},
+		{1234567, "1234567 B"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.size.String(); got != tt.want {
+				t.Errorf("ByteSize.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPercent(t *testing.T) {
+	p := Percent(12.5)
+	if p.String() != "12.5%" {
+		t.Errorf("Percent.String() = %v, want 12.5%%", p.String())
+	}
+	if p.Fraction() != 0.125 {
+		t.Errorf("Percent.Fraction() = %v, want 0.125", p.Fraction())
+	}
+}
@@ -3,6 +3,7 @@ package commander
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestArgument_GetValue(t *testing.T) {
@@ -73,6 +74,54 @@ func TestArgument_GetValue(t *testing.T) {
 			parameter: "/ajshdjkashdjashdjasd",
 			want:      "",
 		},
+		{
+			name: "Bool",
+			fields: fields{
+				Type: "Bool",
+			},
+			parameter: "yes",
+			want:      true,
+		},
+		{
+			name: "Bool [false]",
+			fields: fields{
+				Type: "Bool",
+			},
+			parameter: "0",
+			want:      false,
+		},
+		{
+			name: "Float64",
+			fields: fields{
+				Type: "Float64",
+			},
+			parameter: "3.14",
+			want:      float64(3.14),
+		},
+		{
+			name: "Duration",
+			fields: fields{
+				Type: "Duration",
+			},
+			parameter: "2h30s",
+			want:      2*time.Hour + 30*time.Second,
+		},
+		{
+			name: "Time [RFC3339]",
+			fields: fields{
+				Type: "Time",
+			},
+			parameter: "2019-03-04T05:06:07Z",
+			want:      time.Date(2019, 3, 4, 5, 6, 7, 0, time.UTC),
+		},
+		{
+			name: "Time [date-only]",
+			fields: fields{
+				Type: "Time",
+			},
+			parameter: "2019-03-04",
+			want:      time.Date(2019, 3, 4, 0, 0, 0, 0, time.UTC),
+		},
 		{
 			name: "StringArray[]",
 			fields: fields{
@@ -96,3 +145,57 @@ func TestArgument_GetValue(t *testing.T) {
 		})
 	}
 }
+
+func TestArgument_SetValue_errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		argType   string
+		parameter string
+		want      string
+	}{
+		{name: "Bool", argType: "Bool", parameter: "maybe", want: "expected a boolean: true/false, yes/no, on/off or 1/0"},
+		{name: "Float64", argType: "Float64", parameter: "pi", want: "expected a floating point number like 3.14 or 1e-3"},
+		{name: "Duration", argType: "Duration", parameter: "2 hours", want: "expected a duration like 30s, 1.5m or 2h45m"},
+		{name: "Choices", argType: "String", parameter: "xml", want: "must be one of: json, yaml, table"},
+		{name: "Choices [case-sensitive]", argType: "String", parameter: "JSON", want: "must be one of: json, yaml, table"},
+		{name: "Time", argType: "Time", parameter: "04/03/2019", want: "expected a time in RFC3339 (2006-01-02T15:04:05Z07:00) or date-only (2006-01-02) format"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: tt.argType}
+			if tt.argType == "String" {
+				a.Choices = []string{"json", "yaml", "table"}
+			}
+			err := a.SetValue(tt.parameter)
+			if err == nil || err.Error() != tt.want {
+				t.Errorf("Argument.SetValue() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgument_SetValue_choices(t *testing.T) {
+	tests := []struct {
+		name       string
+		argType    string
+		choices    []string
+		ignoreCase bool
+		parameter  string
+		want       interface{}
+	}{
+		{name: "Exact match", argType: "String", choices: []string{"json", "yaml"}, parameter: "yaml", want: "yaml"},
+		{name: "Ignore case", argType: "String", choices: []string{"json", "yaml"}, ignoreCase: true, parameter: "YAML", want: "yaml"},
+		{name: "Typed choices", argType: "Int64", choices: []string{"1", "2", "4"}, parameter: "4", want: int64(4)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := &Argument{Type: tt.argType, Choices: tt.choices, IgnoreCase: tt.ignoreCase}
+			if err := a.SetValue(tt.parameter); err != nil {
+				t.Fatalf("Argument.SetValue() error = %v", err)
+			}
+			if !reflect.DeepEqual(a.Value, tt.want) {
+				t.Errorf("Argument.Value = %v, want %v", a.Value, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@ package commander
 import (
 	"errors"
 	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strconv"
 	"strings"
 )
 
@@ -20,8 +24,23 @@ type CommandHelper struct {
 	Opts map[string]string
 	// Non-flag arguments
 	Args []string
+	// Stdin is the standard input for Input and Content arguments,
+	// os.Stdin if not defined
+	Stdin io.Reader
+	// Types are the available argument types,
+	// the types shared by all registries if not defined
+	Types *TypeRegistry
+	// Config provides values from configuration files, optional
+	Config ConfigSource
+	// Warnings are the invalid optional arguments,
+	// their values are not set
+	Warnings ValidationErrors
 
-	argList []*Argument
+	argList       []*Argument
+	flagList      []*Flag
+	optList       map[string][]string
+	explicitFlags map[string]bool
+	stdinUsed     bool
 }
 
 // Log is a logger function for debug messages
@@ -62,6 +81,12 @@ func (c *CommandHelper) Opt(key string) string {
 	return ""
 }
 
+// OptList return with all the values of a repeated option
+// in order of appearance, nil if not exists
+func (c *CommandHelper) OptList(key string) []string {
+	return c.optList[key]
+}
+
 // ErrorForTypedOpt returns an error if the given value for
 // the key is defined but not valid
 func (c *CommandHelper) ErrorForTypedOpt(key string) error {
@@ -70,7 +95,7 @@ func (c *CommandHelper) ErrorForTypedOpt(key string) error {
 			continue
 		}
 
-		return arg.Error
+		return arg.exposedError()
 	}
 
 	return errors.New("key not found")
@@ -84,38 +109,103 @@ func (c *CommandHelper) TypedOpt(key string) interface{} {
 			continue
 		}
 
-		return arg.Value
+		return arg.exposedValue()
 	}
 
 	return ""
 }
 
 // Parse is a helper method that parses all passed arguments
-// flags, opts and arguments
+// flags, opts and arguments.
+// Panics with ValidationErrors if a FailOnError Argument is invalid
+// or a Required Argument is missing. Other invalid arguments
+// are collected in Warnings.
 func (c *CommandHelper) Parse(flag []string) {
+	if errs := c.parse(flag); len(errs) > 0 {
+		panic(errs)
+	}
+}
+
+// isOptionValue reports whether the next argument can be the value
+// of an option: it's not an option itself, except "-" (stdin)
+// and negative numbers
+func isOptionValue(arg string) bool {
+	if !strings.HasPrefix(arg, "-") || arg == "-" {
+		return true
+	}
+
+	_, err := strconv.ParseFloat(arg, 64)
+
+	return err == nil
+}
+
+// parse parses all passed arguments and returns with all
+// the invalid FailOnError and missing Required arguments,
+// problems of other arguments are collected in Warnings
+func (c *CommandHelper) parse(flag []string) ValidationErrors {
 	c.Flags = map[string]bool{}
 	c.Opts = map[string]string{}
+	c.optList = map[string][]string{}
+	c.explicitFlags = map[string]bool{}
+	c.stdinUsed = false
+	c.Warnings = ValidationErrors{}
 
-	if len(flag) < 2 {
-		return
+	arguments := []string{}
+	if len(flag) > 1 {
+		arguments = flag[1:]
 	}
 
-	arguments := flag[1:]
-	for _, arg := range arguments {
+	for index := 0; index < len(arguments); index++ {
+		arg := arguments[index]
 		if len(arg) > 1 && arg[0:2] == "--" {
 			parts := strings.SplitN(arg[2:], "=", 2)
+
+			// declared non-boolean arguments accept the value
+			// as the next argument: --set key=value
+			declared := c.argument(parts[0])
+			if declared == nil && c.secretFileArgument(parts[0]) != nil {
+				declared = &Argument{Name: parts[0], Type: "String"}
+			}
+			if len(parts) < 2 && declared != nil && declared.Type != "Bool" &&
+				index+1 < len(arguments) && isOptionValue(arguments[index+1]) {
+				index++
+				parts = append(parts, arguments[index])
+			}
+
 			if len(parts) > 1 {
 				// has exact value
 				c.Opts[parts[0]] = parts[1]
+				c.optList[parts[0]] = append(c.optList[parts[0]], parts[1])
 			} else {
-				c.Flags[parts[0]] = true
+				c.setFlag(parts[0])
 			}
 			continue
 		}
 
-		if arg[0] == '-' {
-			for _, o := range []byte(arg[1:]) {
-				c.Flags[string(o)] = true
+		if len(arg) > 0 && arg[0] == '-' {
+			letters := arg[1:]
+			for position := 0; position < len(letters); position++ {
+				declared := c.shortArgument(letters[position])
+				if declared == nil || declared.Type == "Bool" {
+					c.setFlag(string(letters[position]))
+					continue
+				}
+
+				// the value of a short option is the rest of the argument
+				// (-ovalue, -o=value) or the next argument (-o value)
+				value := strings.TrimPrefix(letters[position+1:], "=")
+				if position+1 == len(letters) {
+					if index+1 >= len(arguments) || !isOptionValue(arguments[index+1]) {
+						c.setFlag(declared.Name)
+						break
+					}
+					index++
+					value = arguments[index]
+				}
+
+				c.Opts[declared.Name] = value
+				c.optList[declared.Name] = append(c.optList[declared.Name], value)
+				break
 			}
 			continue
 		}
@@ -123,6 +213,12 @@ func (c *CommandHelper) Parse(flag []string) {
 		c.Args = append(c.Args, arg)
 	}
 
+	for _, flag := range c.flagList {
+		if !c.explicitFlags[flag.Name] {
+			c.Flags[flag.Name] = flag.Default
+		}
+	}
+
 	if c.Flags["d"] {
 		c.DebugMode = true
 	}
@@ -131,26 +227,225 @@ func (c *CommandHelper) Parse(flag []string) {
 		c.VerboseMode = true
 	}
 
+	errs := ValidationErrors{}
 	for _, arg := range c.argList {
-		if c.Opt(arg.Name) != "" {
-			arg.SetValue(c.Opt(arg.Name))
-			if arg.Error != nil {
-				errorMessage := fmt.Sprintf(
-					"Invalid argument: --%s=%s [%s]",
-					arg.Name, arg.OriginalValue, arg.Error,
-				)
-
-				if arg.FailOnError {
-					panic(errorMessage)
-				}
+		arg.types = c.types()
+		arg.Source = Source{Kind: SourceCommandLine}
+		value := c.Opt(arg.Name)
+		if arg.Type == "Bool" && value == "" && c.explicitFlags[arg.Name] {
+			value = strconv.FormatBool(c.Flags[arg.Name])
+		}
+
+		if c.types().isList(arg.Type) && len(c.OptList(arg.Name)) > 1 {
+			value = strings.Join(c.OptList(arg.Name), ",")
+		}
+
+		if path, ok := c.Opts[arg.Name+secretFileSuffix]; ok && value == "" && arg.Secret {
+			arg.Source = Source{Kind: SourceCommandLine, Path: path}
+			if value, arg.Error = c.readSecretFile(path); arg.Error != nil {
+				arg.OriginalValue, arg.Value = "", nil
+				errs.Add(&ArgumentError{Argument: arg})
+				continue
+			}
+		}
+
+		if value == "" && arg.Env != "" {
+			value = os.Getenv(arg.Env)
+			arg.Source = Source{Kind: SourceEnv, Env: arg.Env}
+		}
+
+		if value == "" && c.Config != nil {
+			var path, key string
+			value, path, key, _ = c.Config.Lookup(arg.Name)
+			arg.Source = Source{Kind: SourceConfig, Path: path, Key: key}
+		}
+
+		if value == "" {
+			value = arg.Default
+			arg.Source = Source{Kind: SourceDefault}
+		}
 
-				FmtPrintf("%s\n", errorMessage)
+		if value == "" {
+			arg.OriginalValue, arg.Value, arg.Error = "", nil, nil
+			arg.Source = Source{}
+			if arg.Required {
+				errs.Add(&ArgumentError{Argument: arg, Missing: true})
 			}
+			continue
+		}
+
+		if arg.SetValue(value) == nil {
+			c.resolveStdin(arg)
+		}
+
+		if arg.Error == nil {
+			continue
+		}
+
+		if arg.FailOnError || arg.Required {
+			errs.Add(&ArgumentError{Argument: arg})
+			continue
+		}
+
+		c.Warnings.Add(&ArgumentError{Argument: arg})
+	}
+
+	c.maskSecrets()
+
+	return errs
+}
+
+// isPassed reports whether the option has a value from the command line,
+// an environment variable or a config file (Default is not counted),
+// or the flag is explicitly enabled
+func (c *CommandHelper) isPassed(key string) bool {
+	if arg := c.argument(key); arg != nil {
+		switch arg.Source.Kind {
+		case SourceCommandLine, SourceEnv, SourceConfig:
+			return arg.Type != "Bool" || arg.Value == true
+		}
+
+		return false
+	}
+
+	if _, ok := c.Opts[key]; ok {
+		return true
+	}
+
+	return c.explicitFlags[key] && c.Flag(key)
+}
+
+// setFlag sets a flag passed as --name or -n.
+// Declared flags are stored with their Name: -f is "force",
+// --no-force sets "force" to false.
+func (c *CommandHelper) setFlag(name string) {
+	value := true
+	flag := c.flag(name)
+
+	if flag == nil && strings.HasPrefix(name, "no-") {
+		if flag = c.flag(name[3:]); flag != nil {
+			value = false
+		}
+	}
+
+	if flag != nil {
+		name = flag.Name
+	} else if len(name) == 1 {
+		if declared := c.shortArgument(name[0]); declared != nil {
+			name = declared.Name
+		}
+	}
+
+	c.Flags[name] = value
+	c.explicitFlags[name] = true
+}
+
+// shortArgument returns with the attached Argument with the given Short
+func (c *CommandHelper) shortArgument(letter byte) *Argument {
+	for _, arg := range c.argList {
+		if arg.Short != "" && arg.Short == string(letter) {
+			return arg
+		}
+	}
+
+	return nil
+}
+
+// flag returns with the attached Flag with the given name or short name
+func (c *CommandHelper) flag(name string) *Flag {
+	for _, flag := range c.flagList {
+		if flag.Name == name || (flag.Short != "" && flag.Short == name) {
+			return flag
+		}
+	}
+
+	return nil
+}
+
+// resolveStdin passes the standard input to values (or items of
+// list values) reading it, only the first one can use it
+func (c *CommandHelper) resolveStdin(arg *Argument) {
+	if _, ok := arg.Value.(stdinResolver); ok {
+		arg.Value, arg.Error = c.resolveStdinValue(arg.Value)
+		return
+	}
+
+	list := reflect.ValueOf(arg.Value)
+	if list.Kind() != reflect.Slice {
+		return
+	}
+
+	values := make([]interface{}, list.Len())
+	resolved := false
+	for index := range values {
+		values[index] = list.Index(index).Interface()
+		if _, ok := values[index].(stdinResolver); !ok {
+			continue
+		}
+
+		value, err := c.resolveStdinValue(values[index])
+		if err != nil {
+			arg.Value, arg.Error = []interface{}{}, fmt.Errorf("item %d (%s): %s", index+1, StdinName, err)
+			return
+		}
+		values[index], resolved = value, true
+	}
+
+	if resolved {
+		arg.Value = typedSlice(values)
+	}
+}
+
+func (c *CommandHelper) resolveStdinValue(value interface{}) (interface{}, error) {
+	if c.stdinUsed {
+		return nil, errors.New("standard input is already used by another argument")
+	}
+	c.stdinUsed = true
+
+	var stdin io.Reader = os.Stdin
+	if c.Stdin != nil {
+		stdin = c.Stdin
+	}
+
+	return value.(stdinResolver).resolveStdin(stdin)
+}
+
+// argument returns with the attached Argument with the given name
+func (c *CommandHelper) argument(name string) *Argument {
+	for _, arg := range c.argList {
+		if arg.Name == name {
+			return arg
 		}
 	}
+
+	return nil
+}
+
+// Source returns with the source of the value of an argument,
+// SourceNone if the argument has no value or not exists
+func (c *CommandHelper) Source(key string) Source {
+	if arg := c.argument(key); arg != nil {
+		return arg.Source
+	}
+
+	return Source{}
+}
+
+// types returns with Types or the shared types
+func (c *CommandHelper) types() *TypeRegistry {
+	if c.Types == nil {
+		return sharedTypes
+	}
+
+	return c.Types
 }
 
 // AttachArgumentList binds an Argument list to CommandHelper
 func (c *CommandHelper) AttachArgumentList(argumets []*Argument) {
 	c.argList = argumets
 }
+
+// AttachFlagList binds a Flag list to CommandHelper
+func (c *CommandHelper) AttachFlagList(flags []*Flag) {
+	c.flagList = flags
+}
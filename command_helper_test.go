@@ -365,3 +365,104 @@ func TestCommandHelper_Log(t *testing.T) {
 		})
 	}
 }
+
+func TestCommandHelper_Parse_default(t *testing.T) {
+	c := &CommandHelper{}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "cache", Type: "ByteSize", Default: "10MB"},
+		&Argument{Name: "count", Type: "Int64", Default: "3"},
+	})
+	c.Parse([]string{"command", "--count=5"})
+
+	if got := c.TypedOpt("cache"); got != ByteSize(10000000) {
+		t.Errorf("CommandHelper.TypedOpt(cache) = %v, want default 10 MB", got)
+	}
+
+	if got := c.TypedOpt("count"); got != int64(5) {
+		t.Errorf("CommandHelper.TypedOpt(count) = %v, want 5", got)
+	}
+}
+
+func TestCommandHelper_Parse_shortOptions(t *testing.T) {
+	tests := []struct {
+		name  string
+		flag  []string
+		opts  map[string]string
+		flags map[string]bool
+		typed map[string]interface{}
+		args  []string
+	}{
+		{name: "Separate value", flag: []string{"cmd", "-o", "out.txt"}, opts: map[string]string{"output": "out.txt"}},
+		{name: "Attached value", flag: []string{"cmd", "-oout.txt"}, opts: map[string]string{"output": "out.txt"}},
+		{name: "Equal sign", flag: []string{"cmd", "-o=out.txt"}, opts: map[string]string{"output": "out.txt"}},
+		{
+			name:  "Bundled flags",
+			flag:  []string{"cmd", "-xvo", "out.txt", "arg"},
+			opts:  map[string]string{"output": "out.txt"},
+			flags: map[string]bool{"x": true, "v": true},
+			args:  []string{"arg"},
+		},
+		{
+			name:  "Bundled flags with attached value",
+			flag:  []string{"cmd", "-xocount", "arg"},
+			opts:  map[string]string{"output": "count"},
+			flags: map[string]bool{"x": true, "c": false},
+			args:  []string{"arg"},
+		},
+		{name: "Missing value", flag: []string{"cmd", "-o"}, opts: map[string]string{"output": ""}, flags: map[string]bool{"output": true}},
+		{
+			name:  "Option as next argument",
+			flag:  []string{"cmd", "--output", "--force"},
+			opts:  map[string]string{"output": ""},
+			flags: map[string]bool{"output": true, "force": true},
+		},
+		{
+			name:  "Short option as next argument",
+			flag:  []string{"cmd", "-o", "-e"},
+			opts:  map[string]string{"output": ""},
+			flags: map[string]bool{"output": true, "enabled": true},
+		},
+		{name: "Negative number", flag: []string{"cmd", "--output", "-1.5"}, opts: map[string]string{"output": "-1.5"}},
+		{name: "Stdin", flag: []string{"cmd", "-o", "-"}, opts: map[string]string{"output": "-"}},
+		{
+			name:  "Bool argument",
+			flag:  []string{"cmd", "-e"},
+			flags: map[string]bool{"enabled": true, "e": false},
+			typed: map[string]interface{}{"enabled": true},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CommandHelper{}
+			c.AttachArgumentList([]*Argument{
+				&Argument{Name: "output", Short: "o", Type: "String"},
+				&Argument{Name: "enabled", Short: "e", Type: "Bool"},
+			})
+			c.Parse(tt.flag)
+
+			for key, want := range tt.opts {
+				if got := c.Opt(key); got != want {
+					t.Errorf("CommandHelper.Opt(%s) = %v, want %v", key, got, want)
+				}
+			}
+
+			for key, want := range tt.flags {
+				if got := c.Flag(key); got != want {
+					t.Errorf("CommandHelper.Flag(%s) = %v, want %v", key, got, want)
+				}
+			}
+
+			for index, want := range tt.args {
+				if got := c.Arg(index); got != want {
+					t.Errorf("CommandHelper.Arg(%d) = %v, want %v", index, got, want)
+				}
+			}
+
+			for key, want := range tt.typed {
+				if got := c.TypedOpt(key); got != want {
+					t.Errorf("CommandHelper.TypedOpt(%s) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
@@ -1,9 +1,14 @@
 package commander
 
 import (
+	"bytes"
+	"errors"
 	"flag"
 	"fmt"
+	"io"
+	"os"
 	"path"
+	"sort"
 	"strings"
 )
 
@@ -13,6 +18,29 @@ type CommandRegistry struct {
 	Commands map[string]*CommandWrapper
 	Helper   *CommandHelper
 	Depth    int
+	// HelpRenderer renders Help and CommandHelp,
+	// nil means the default text/template based renderer
+	HelpRenderer HelpRenderer
+	// Theme styles help and error output, nil means DefaultTheme.
+	// Colors are disabled if the output is not a terminal,
+	// NO_COLOR environment variable is set or --no-color is passed.
+	Theme *Theme
+	// DisablePager turns off paging of long help output.
+	// Help longer than the terminal is sent through $PAGER
+	// (or DefaultPager) unless --no-pager is passed.
+	DisablePager bool
+	// Stdin is passed to CommandHelper, os.Stdin if not defined
+	Stdin io.Reader
+	// Strict rejects options and flags that are not declared
+	// on the called command, see CommandWrapper.Strict
+	Strict bool
+	// Types are the argument types of the commands in this registry,
+	// they inherit the types registered with RegisterArgumentType.
+	// Types used by Options have to be registered before Register.
+	Types *TypeRegistry
+	// Config provides option values from configuration files,
+	// see ConfigSource
+	Config ConfigSource
 
 	maximumCommandLength int
 }
@@ -21,6 +49,15 @@ type CommandRegistry struct {
 func (c *CommandRegistry) Register(f NewCommandFunc) {
 	wrapper := f(c.executableName())
 	name := wrapper.Help.Name
+
+	if wrapper.Options != nil {
+		arguments, err := argumentsFromStruct(wrapper.Options, c.types())
+		if err != nil {
+			panic(fmt.Sprintf("invalid Options of %s: %s", name, err))
+		}
+		wrapper.Arguments = append(wrapper.Arguments, arguments...)
+	}
+
 	c.Commands[name] = wrapper
 	commandLength := len(fmt.Sprintf("%s %s", name, wrapper.Help.Arguments))
 	if commandLength > c.maximumCommandLength {
@@ -33,86 +70,387 @@ func (c *CommandRegistry) Register(f NewCommandFunc) {
 // if something went wrong or the user asked for it.
 func (c *CommandRegistry) Execute() {
 	name := flag.Arg(c.Depth)
-	c.Helper = &CommandHelper{}
+	c.Helper = &CommandHelper{Stdin: c.Stdin, Types: c.types(), Config: c.Config}
 	if command, ok := c.Commands[name]; ok {
 		defer func() {
 			if err := recover(); err != nil {
-				FmtPrintf("[E] %s\n\n", err)
+				for _, line := range strings.Split(fmt.Sprint(err), "\n") {
+					FmtPrintf("%s\n", c.theme().Error("[E] "+line))
+				}
+				FmtPrintf("\n")
 				c.CommandHelp(name)
 			}
 		}()
 
 		c.Helper.AttachArgumentList(command.Arguments)
-		c.Helper.Parse(flag.Args()[c.Depth:])
+		c.Helper.AttachFlagList(command.Flags)
+		errs := c.validate(command)
+		c.printWarnings()
+		if len(errs) > 0 {
+			panic(errs)
+		}
 
-		if command.Validator != nil {
-			command.Validator(c.Helper)
+		if command.Options != nil {
+			if err := BindArguments(command.Options, command.Arguments); err != nil {
+				panic(err)
+			}
 		}
+
 		command.Handler.Execute(c.Helper)
 	} else {
 		if (name != "help") && (name != "") {
-			FmtPrintf("Command not found: %s\n", name)
+			FmtPrintf("%s\n", c.theme().Error("Command not found: "+name))
 		}
 		c.Help()
 	}
 }
 
+// validate parses the arguments and collects all the problems:
+// invalid or missing arguments, option rules and validator errors
+func (c *CommandRegistry) validate(command *CommandWrapper) ValidationErrors {
+	errs := c.Helper.parse(flag.Args()[c.Depth:])
+	c.Helper.logResolvedArguments()
+
+	if c.Strict || command.Strict {
+		errs.Add(checkStrict(command, c.Helper))
+	}
+
+	for _, err := range checkRules(command.Rules, c.Helper) {
+		errs.Add(err)
+	}
+
+	if command.Validator != nil {
+		errs.Add(runValidator(command.Validator, c.Helper))
+	}
+
+	if command.Validate != nil {
+		errs.Add(command.Validate(c.Helper))
+	}
+
+	return errs
+}
+
+// printWarnings prints the invalid optional arguments,
+// they are reported before the errors of validate
+func (c *CommandRegistry) printWarnings() {
+	for _, warning := range c.Helper.Warnings {
+		for _, line := range strings.Split(warning.Error(), "\n") {
+			FmtPrintf("%s\n", c.theme().Error("[W] "+line))
+		}
+	}
+}
+
+// runValidator converts the panic of a ValidatorFunc to an error
+func runValidator(validator ValidatorFunc, helper *CommandHelper) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			if e, ok := r.(error); ok {
+				err = e
+			} else {
+				err = fmt.Errorf("%v", r)
+			}
+		}
+	}()
+
+	validator(helper)
+
+	return nil
+}
+
 // Help lists all available commands to the user
 func (c *CommandRegistry) Help() {
-	if flag.Arg(c.Depth) == "help" && flag.Arg(c.Depth+1) != "" {
-		c.CommandHelp(flag.Arg(c.Depth + 1))
+	// help --no-pager should list all the commands
+	if name := flag.Arg(c.Depth + 1); flag.Arg(c.Depth) == "help" && name != "" && name[0] != '-' {
+		c.CommandHelp(name)
 		return
 	}
 
-	format := fmt.Sprintf("%%-%ds   %%s\n", c.maximumCommandLength)
-	for name, command := range c.Commands {
-		FmtPrintf(
-			format,
-			fmt.Sprintf("%s %s", name, command.Help.Arguments),
-			command.Help.ShortDescription,
-		)
+	data := &HelpData{
+		ExecutableName: c.executableName(),
+		SignatureWidth: c.maximumCommandLength,
+		Width:          terminalWidth(),
+		Theme:          c.theme(),
+	}
+
+	names := make([]string, 0, len(c.Commands))
+	for name := range c.Commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		command := c.Commands[name]
+		data.Commands = append(data.Commands, HelpCommand{
+			Name:             name,
+			Signature:        fmt.Sprintf("%s %s", name, command.Help.Arguments),
+			ShortDescription: command.Help.ShortDescription,
+		})
 	}
-	FmtPrintf(
-		format,
-		"help [command]",
-		"Display this help or a command specific help",
-	)
+	data.Commands = append(data.Commands, HelpCommand{
+		Name:             "help",
+		Signature:        "help [command]",
+		ShortDescription: "Display this help or a command specific help",
+	})
+
+	if length := len("help [command]"); length > data.SignatureWidth {
+		data.SignatureWidth = length
+	}
+
+	// long signatures should not push descriptions to the far right
+	if maximum := data.Width * 2 / 5; data.Width > 0 && data.SignatureWidth > maximum {
+		data.SignatureWidth = maximum
+	}
+
+	var output bytes.Buffer
+	if err := c.helpRenderer().RenderHelp(&output, data); err != nil {
+		FmtPrintf("%s\n", c.theme().Error(fmt.Sprintf("[E] %s", err)))
+		return
+	}
+
+	c.printHelp(output.String())
 }
 
 // CommandHelp prints more detailed help for a specific Command
 func (c *CommandRegistry) CommandHelp(name string) {
-	if command, ok := c.Commands[name]; ok {
-		extra := ""
-		if c.Depth > 0 {
-			extra = strings.Join(flag.Args()[0:c.Depth], " ")
-		}
-		if len(extra) > 0 {
-			extra += " "
+	command, ok := c.Commands[name]
+	if !ok {
+		return
+	}
+
+	prefix := ""
+	if c.Depth > 0 {
+		prefix = strings.Join(flag.Args()[0:c.Depth], " ") + " "
+	}
+
+	data := &CommandHelpData{
+		ExecutableName:  c.executableName(),
+		Prefix:          prefix,
+		Name:            name,
+		Arguments:       command.Help.Arguments,
+		LongDescription: command.Help.LongDescription,
+		Examples:        command.Help.Examples,
+		Rules:           make([]string, 0, len(command.Rules)),
+		Width:           terminalWidth(),
+		Theme:           c.theme(),
+	}
+
+	for _, rule := range command.Rules {
+		data.Rules = append(data.Rules, rule.String())
+	}
+
+	// long names are aligned if any option or flag has a short alias
+	align := false
+	for _, arg := range command.Arguments {
+		align = align || arg.Short != ""
+	}
+	for _, flag := range command.Flags {
+		align = align || flag.Short != ""
+	}
+
+	groupIndex := map[string]int{}
+	for _, arg := range command.Arguments {
+		arg.types = c.types()
+		options := []HelpOption{newHelpOption(arg, align)}
+		if arg.Secret {
+			options = append(options, newSecretFileOption(arg, align))
 		}
-		FmtPrintf("Usage: %s %s%s %s\n", c.executableName(), extra, name, command.Help.Arguments)
 
-		if command.Help.LongDescription != "" {
-			FmtPrintf("")
-			FmtPrintf(command.Help.LongDescription)
+		index, ok := groupIndex[arg.Group]
+		if !ok {
+			index = len(data.OptionGroups)
+			groupIndex[arg.Group] = index
+			data.OptionGroups = append(data.OptionGroups, HelpOptionGroup{Name: arg.Group})
 		}
 
-		for _, arg := range command.Arguments {
-			extra = " "
-			if arg.FailOnError {
-				extra += "<required>"
-			} else {
-				extra += "[optional]"
+		for _, option := range options {
+			data.Options = append(data.Options, option)
+			data.OptionGroups[index].Options = append(data.OptionGroups[index].Options, option)
+
+			if length := len(option.Signature) + 2; length > data.OptionWidth {
+				data.OptionWidth = length
 			}
-			FmtPrintf("  --%s=%s%s\n", arg.Name, arg.Type, extra)
 		}
+	}
 
-		if len(command.Help.Examples) > 0 {
-			FmtPrintf("\nExamples:\n")
-			for _, line := range command.Help.Examples {
-				FmtPrintf("  %s %s%s %s\n", c.executableName(), extra, name, line)
-			}
+	for _, flag := range command.Flags {
+		helpFlag := newHelpFlag(flag, align)
+		data.Flags = append(data.Flags, helpFlag)
+
+		if length := len(helpFlag.Signature) + 2; length > data.OptionWidth {
+			data.OptionWidth = length
+		}
+	}
+
+	if maximum := data.Width * 2 / 5; data.Width > 0 && data.OptionWidth > maximum {
+		data.OptionWidth = maximum
+	}
+
+	var output bytes.Buffer
+	if err := c.helpRenderer().RenderCommandHelp(&output, data); err != nil {
+		FmtPrintf("%s\n", c.theme().Error(fmt.Sprintf("[E] %s", err)))
+		return
+	}
+
+	c.printHelp(output.String())
+}
+
+func newHelpOption(arg *Argument, align bool) HelpOption {
+	option := HelpOption{
+		Name:        arg.Name,
+		Short:       arg.Short,
+		Type:        arg.Type,
+		Required:    arg.FailOnError || arg.Required,
+		Description: arg.Description,
+		Placeholder: arg.Placeholder,
+		Choices:     arg.Choices,
+	}
+
+	if option.Placeholder == "" && len(arg.Choices) > 0 {
+		option.Placeholder = strings.Join(arg.Choices, "|")
+	}
+
+	if option.Placeholder == "" {
+		option.Placeholder = arg.Type
+	}
+
+	// parsed value is more readable: 1.5 GiB instead of 1610612736
+	option.Default = arg.formatBound(arg.Default)
+	if arg.Secret && option.Default != "" {
+		option.Default = SecretMask
+	}
+
+	option.Constraints = arg.constraintDescriptions()
+
+	details := []string{}
+	if option.Default != "" {
+		details = append(details, "default: "+option.Default)
+	}
+	details = append(details, option.Constraints...)
+
+	option.Summary = option.Description
+	if len(details) > 0 {
+		option.Summary = strings.TrimSpace(fmt.Sprintf("%s (%s)", option.Summary, strings.Join(details, ", ")))
+	}
+
+	option.Signature = fmt.Sprintf("%s--%s=%s ", shortPrefix(arg.Short, align), option.Name, option.Placeholder)
+	if option.Required {
+		option.Signature += "<required>"
+	} else {
+		option.Signature += "[optional]"
+	}
+
+	return option
+}
+
+// newSecretFileOption describes the --name-file option
+// of a Secret argument
+func newSecretFileOption(arg *Argument, align bool) HelpOption {
+	option := HelpOption{
+		Name:        arg.Name + secretFileSuffix,
+		Type:        "String",
+		Placeholder: "FILE",
+		Summary:     fmt.Sprintf("Read --%s from FILE, - for standard input", arg.Name),
+	}
+	option.Description = option.Summary
+	option.Signature = fmt.Sprintf("%s--%s=%s [optional]", shortPrefix("", align), option.Name, option.Placeholder)
+
+	return option
+}
+
+// theme returns with the active Theme,
+// or with a plain one if colors are disabled
+func (c *CommandRegistry) theme() *Theme {
+	if !c.colorEnabled() {
+		return plainTheme
+	}
+
+	if c.Theme != nil {
+		return c.Theme
+	}
+
+	return DefaultTheme
+}
+
+func (c *CommandRegistry) colorEnabled() bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+
+	if hasArgument("--no-color") {
+		return false
+	}
+
+	return OutputIsTerminal()
+}
+
+// hasArgument reports whether the given argument is passed
+func hasArgument(name string) bool {
+	for _, arg := range flag.Args() {
+		if arg == name {
+			return true
 		}
 	}
+
+	return false
+}
+
+func newHelpFlag(flag *Flag, align bool) HelpFlag {
+	helpFlag := HelpFlag{
+		Name:        flag.Name,
+		Short:       flag.Short,
+		Description: flag.Description,
+		Default:     flag.Default,
+		Signature:   shortPrefix(flag.Short, align) + "--" + flag.Name,
+		Summary:     flag.Description,
+	}
+
+	if flag.Default {
+		helpFlag.Summary = strings.TrimSpace(fmt.Sprintf("%s (default: true, --no-%s to disable)", flag.Description, flag.Name))
+	}
+
+	return helpFlag
+}
+
+// shortPrefix returns with "-s, " or with spaces in the same width
+// if there is no short alias, but long names have to be aligned
+func shortPrefix(short string, align bool) string {
+	if short != "" {
+		return "-" + short + ", "
+	}
+
+	if align {
+		return "    "
+	}
+
+	return ""
+}
+
+func (c *CommandRegistry) helpRenderer() HelpRenderer {
+	if c.HelpRenderer != nil {
+		return c.HelpRenderer
+	}
+
+	return defaultHelpRenderer
+}
+
+func (c *CommandRegistry) printHelp(output string) {
+	// the output is printed only if the pager could not be started,
+	// otherwise the user has already seen it
+	if c.pagerEnabled() && needsPager(output) {
+		if err := RunPager(pagerCommand(), output); !errors.Is(err, ErrPagerNotStarted) {
+			return
+		}
+	}
+
+	FmtPrintf("%s", output)
+}
+
+func (c *CommandRegistry) pagerEnabled() bool {
+	if c.DisablePager {
+		return false
+	}
+
+	return !hasArgument("--no-pager")
 }
 
 // Determine the name of the executable
@@ -127,5 +465,15 @@ func NewCommandRegistry() *CommandRegistry {
 	flag.Parse()
 	return &CommandRegistry{
 		Commands: map[string]*CommandWrapper{},
+		Types:    NewTypeRegistry(sharedTypes),
 	}
 }
+
+// types returns with Types or the shared types
+func (c *CommandRegistry) types() *TypeRegistry {
+	if c.Types == nil {
+		return sharedTypes
+	}
+
+	return c.Types
+}
@@ -88,6 +88,12 @@ func mockEverything() {
 		return "/some/random/path/my-executable", nil
 	}
 
+	OutputIsTerminal = func() bool {
+		return false
+	}
+	os.Unsetenv("COLUMNS")
+	os.Unsetenv("NO_COLOR")
+
 	mockOutput = ""
 	FmtPrintf = mockPrintf
 }
@@ -366,7 +372,7 @@ description about this command.`,
 					return "Command should be called with VerboseMode"
 				}
 
-				value := "Invalid argument: --owner=asd:yitsushi [Invalid format! MyType => 'ID:Name'"
+				value := "[W] Invalid argument: --owner=asd:yitsushi [Invalid format! MyType => 'ID:Name'"
 				if !strings.Contains(output, value) {
 					return fmt.Sprintf("value(%s) not found in output(%s)", value, output)
 				}
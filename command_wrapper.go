@@ -19,6 +19,10 @@ type NewCommandFunc func(appName string) *CommandWrapper
 // Just throw a panic if something is wrong
 type ValidatorFunc func(opts *CommandHelper)
 
+// ValidateFunc can pre-validate the command and it's arguments
+// Return with an error (or ValidationErrors) if something is wrong
+type ValidateFunc func(opts *CommandHelper) error
+
 // CommandWrapper is a general wrapper for a command
 // CommandRegistry will know what to do this a struct like this
 type CommandWrapper struct {
@@ -28,6 +32,36 @@ type CommandWrapper struct {
 	Handler CommandHandler
 	// Validator will be executed before Execute on the Handler
 	Validator ValidatorFunc
+	// Validate will be executed before Execute on the Handler,
+	// its errors are reported together with all the other problems
+	Validate ValidateFunc
 	// Arguments is a simple list of possible arguments with type definition
 	Arguments []*Argument
+	// Options is an optional pointer to a struct with tagged fields
+	// (see StructTag). Its fields are added to Arguments on Register
+	// and set from the parsed values before Execute.
+	Options interface{}
+	// Flags are the declared boolean flags of the command
+	Flags []*Flag
+	// Strict rejects options and flags that are not declared
+	// in Arguments or Flags
+	Strict bool
+	// Rules are relationships between options like Conflicts("all", "name"),
+	// checked before Validator and listed in the command specific help
+	Rules []OptionRule
+}
+
+// Flag is a declared boolean flag of a command.
+// The value is stored in CommandHelper.Flags with Name as key
+// if the flag is passed as --name or -short,
+// --no-name sets it to false.
+type Flag struct {
+	// Name of the flag without dashes: "force" for --force
+	Name string
+	// Short is an optional single letter alias: "f" for -f
+	Short string
+	// Description is displayed next to the flag in CommandHelp
+	Description string
+	// Default is the value if the flag is not passed
+	Default bool
 }
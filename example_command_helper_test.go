@@ -1,6 +1,7 @@
 package commander_test
 
 import (
+	"errors"
 	"fmt"
 	"log"
 
@@ -61,3 +62,22 @@ func ExampleCommandHelper_Flag() {
 	// Output: -l is defined
 	// Color mode is disabled
 }
+
+func ExampleGet() {
+	opts.AttachArgumentList([]*commander.Argument{
+		&commander.Argument{Name: "count", Type: "Int64"},
+		&commander.Argument{Name: "name", Type: "String"},
+	})
+	opts.Parse([]string{"my-command", "--count=3"})
+
+	count, err := commander.Get[int64](opts, "count")
+	fmt.Println(count, err)
+
+	// Not passed: zero value and ErrAbsentOption
+	name, err := commander.Get[string](opts, "name")
+	fmt.Printf("%q %v\n", name, errors.Is(err, commander.ErrAbsentOption))
+
+	// Output:
+	// 3 <nil>
+	// "" true
+}
@@ -0,0 +1,78 @@
+package commander
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCommandHelper_Parse_declaredFlags(t *testing.T) {
+	flags := []*Flag{
+		&Flag{Name: "force", Short: "f"},
+		&Flag{Name: "color", Default: true},
+		&Flag{Name: "dry-run", Short: "n"},
+	}
+
+	tests := []struct {
+		name string
+		flag []string
+		want map[string]bool
+	}{
+		{name: "Defaults", flag: []string{"cmd"}, want: map[string]bool{"force": false, "color": true, "dry-run": false}},
+		{name: "Long name", flag: []string{"cmd", "--force"}, want: map[string]bool{"force": true, "f": false}},
+		{name: "Short name", flag: []string{"cmd", "-f"}, want: map[string]bool{"force": true, "f": false}},
+		{name: "Bundled short names", flag: []string{"cmd", "-vfn"}, want: map[string]bool{"force": true, "dry-run": true, "v": true}},
+		{name: "Negation", flag: []string{"cmd", "--no-color"}, want: map[string]bool{"color": false, "no-color": false}},
+		{name: "Last one wins", flag: []string{"cmd", "--no-force", "-f", "--color", "--no-color"}, want: map[string]bool{"force": true, "color": false}},
+		{name: "Undeclared", flag: []string{"cmd", "--no-other", "-x"}, want: map[string]bool{"no-other": true, "x": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CommandHelper{}
+			c.AttachFlagList(flags)
+			c.Parse(tt.flag)
+
+			for key, want := range tt.want {
+				if got := c.Flag(key); got != want {
+					t.Errorf("CommandHelper.Flag(%s) = %v, want %v", key, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCommandRegistry_CommandHelp_flags(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"/some/random/path/my-executable", "help", "my-command"}
+	c := NewCommandRegistry()
+	c.Register(func(appName string) *CommandWrapper {
+		return &CommandWrapper{
+			Handler: &MyCommand{},
+			Arguments: []*Argument{
+				&Argument{Name: "name", Type: "String", Description: "Name of the thing"},
+			},
+			Flags: []*Flag{
+				&Flag{Name: "force", Short: "f", Description: "Do it anyway"},
+				&Flag{Name: "color", Default: true},
+			},
+			Help: &CommandDescriptor{Name: "my-command"},
+		}
+	})
+	c.Execute()
+
+	want := `
+Options:
+      --name=String [optional]   Name of the thing
+
+Flags:
+  -f, --force                    Do it anyway
+      --color                    (default: true, --no-color to disable)
+`
+	if !strings.Contains(mockOutput, want) {
+		t.Errorf("value(%s) not found in output(%s)", want, mockOutput)
+	}
+}
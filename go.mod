@@ -1,6 +1,6 @@
 module github.com/yitsushi/go-commander
 
-go 1.13
+go 1.18
 
 require (
 	github.com/kardianos/osext v0.0.0-20190222173326-2bc1f35cddc0
@@ -0,0 +1,323 @@
+package commander
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+)
+
+// HelpRenderer renders general and command specific help.
+// Set CommandRegistry.HelpRenderer to replace the default layout.
+type HelpRenderer interface {
+	// RenderHelp renders the general help with all available commands
+	RenderHelp(w io.Writer, data *HelpData) error
+	// RenderCommandHelp renders the help of a single command
+	RenderCommandHelp(w io.Writer, data *CommandHelpData) error
+}
+
+// HelpCommand is a single line in the general help
+type HelpCommand struct {
+	// Name of the command
+	Name string
+	// Signature is the name and the argument list together
+	Signature string
+	// ShortDescription of the command
+	ShortDescription string
+}
+
+// HelpData is passed to HelpRenderer.RenderHelp
+type HelpData struct {
+	// ExecutableName is the name of the running executable
+	ExecutableName string
+	// Commands is the list of registered commands ordered by name,
+	// the built-in help command is always the last one
+	Commands []HelpCommand
+	// SignatureWidth is the width of the Signature column,
+	// the length of the longest Signature limited by Width
+	SignatureWidth int
+	// Width of the output in columns, 0 if unknown
+	Width int
+	// Theme styles the output, it's a plain theme if colors are disabled
+	Theme *Theme
+}
+
+// HelpOption describes an Argument in the command specific help
+type HelpOption struct {
+	// Name of the option without leading dashes
+	Name string
+	// Short is the single letter alias
+	Short string
+	// Type of the option
+	Type string
+	// Required is true if the Argument is Required or has FailOnError
+	Required bool
+	// Description of the option
+	Description string
+	// Placeholder is the name of the value, if not defined
+	// it's the list of Choices (json|yaml) or the Type
+	Placeholder string
+	// Choices is the list of accepted values
+	Choices []string
+	// Default is the default value as declared
+	Default string
+	// Constraints are the human-readable constraints: "range: 1..10"
+	Constraints []string
+	// Summary is the Description extended with the Default value
+	// and the Constraints
+	Summary string
+	// Signature is the option with its short alias, placeholder and
+	// required/optional marker: -n, --name=PLACEHOLDER [optional]
+	Signature string
+}
+
+// HelpFlag describes a declared Flag in the command specific help
+type HelpFlag struct {
+	// Name of the flag without dashes
+	Name string
+	// Short is the single letter alias
+	Short string
+	// Description of the flag
+	Description string
+	// Default value of the flag
+	Default bool
+	// Signature is the flag with its alias: -f, --force
+	Signature string
+	// Summary is the Description extended with the Default value
+	Summary string
+}
+
+// HelpOptionGroup is a named list of options
+type HelpOptionGroup struct {
+	// Name of the group, empty for options without group
+	Name string
+	// Options in the group
+	Options []HelpOption
+}
+
+// CommandHelpData is passed to HelpRenderer.RenderCommandHelp
+type CommandHelpData struct {
+	// ExecutableName is the name of the running executable
+	ExecutableName string
+	// Prefix contains the parent command names for subcommands
+	// with a trailing space, empty string for top level commands
+	Prefix string
+	// Name of the command
+	Name string
+	// Arguments is the argument list of the command as a string
+	Arguments string
+	// LongDescription of the command
+	LongDescription string
+	// Options are the typed Arguments of the command
+	Options []HelpOption
+	// OptionGroups are the Options grouped by Argument.Group
+	// in order of their first appearance
+	OptionGroups []HelpOptionGroup
+	// Flags are the declared Flags of the command
+	Flags []HelpFlag
+	// OptionWidth is the width of the option and flag column
+	// including the two spaces indentation before Signature
+	OptionWidth int
+	// Examples of the command
+	Examples []string
+	// Rules are the descriptions of the option rules
+	Rules []string
+	// Width of the output in columns, 0 if unknown
+	Width int
+	// Theme styles the output, it's a plain theme if colors are disabled
+	Theme *Theme
+}
+
+// DefaultHelpTemplate is the text/template used for the general help
+const DefaultHelpTemplate = `{{range .Commands}}{{columns ($.Theme.Command .Signature) .ShortDescription $.SignatureWidth $.Width}}
+{{end}}`
+
+// DefaultCommandHelpTemplate is the text/template used for
+// the command specific help
+const DefaultCommandHelpTemplate = `{{.Theme.Heading "Usage:"}} {{.ExecutableName}} {{.Prefix}}{{.Theme.Command .Name}} {{.Arguments}}
+{{if .LongDescription}}
+{{wrap .Width 0 .LongDescription}}
+{{end}}{{range .OptionGroups}}
+{{if .Name}}{{$.Theme.Heading (printf "%s:" .Name)}}{{else}}{{$.Theme.Heading "Options:"}}{{end}}
+{{range .Options}}{{columns (printf "  %s" ($.Theme.Option .Signature)) .Summary $.OptionWidth $.Width}}
+{{end}}{{end}}{{if .Flags}}
+{{.Theme.Heading "Flags:"}}
+{{range .Flags}}{{columns (printf "  %s" ($.Theme.Option .Signature)) .Summary $.OptionWidth $.Width}}
+{{end}}{{end}}{{if .Rules}}
+{{.Theme.Heading "Rules:"}}
+{{range .Rules}}  {{wrap $.Width 2 .}}
+{{end}}{{end}}{{if .Examples}}
+{{.Theme.Heading "Examples:"}}
+{{range .Examples}}  {{$.ExecutableName}} {{$.Prefix}}{{$.Name}} {{.}}
+{{end}}{{end}}`
+
+// TemplateHelpRenderer is a HelpRenderer based on text/template
+type TemplateHelpRenderer struct {
+	// Header is printed before every rendered help
+	Header string
+	// Footer is printed after every rendered help
+	Footer string
+
+	help        *template.Template
+	commandHelp *template.Template
+}
+
+// NewTemplateHelpRenderer creates a TemplateHelpRenderer from
+// the given templates. Empty template means the default one.
+// Available functions in templates: pad, columns, wrap, join, upper, lower
+func NewTemplateHelpRenderer(helpTemplate, commandHelpTemplate string) (*TemplateHelpRenderer, error) {
+	if helpTemplate == "" {
+		helpTemplate = DefaultHelpTemplate
+	}
+
+	if commandHelpTemplate == "" {
+		commandHelpTemplate = DefaultCommandHelpTemplate
+	}
+
+	help, err := template.New("help").Funcs(helpTemplateFuncs()).Parse(helpTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	commandHelp, err := template.New("command-help").Funcs(helpTemplateFuncs()).Parse(commandHelpTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateHelpRenderer{
+		help:        help,
+		commandHelp: commandHelp,
+	}, nil
+}
+
+// RenderHelp executes the general help template
+func (r *TemplateHelpRenderer) RenderHelp(w io.Writer, data *HelpData) error {
+	if data.Theme == nil {
+		data.Theme = plainTheme
+	}
+
+	return r.render(w, r.help, data)
+}
+
+// RenderCommandHelp executes the command specific help template
+func (r *TemplateHelpRenderer) RenderCommandHelp(w io.Writer, data *CommandHelpData) error {
+	if data.Theme == nil {
+		data.Theme = plainTheme
+	}
+
+	return r.render(w, r.commandHelp, data)
+}
+
+func (r *TemplateHelpRenderer) render(w io.Writer, tpl *template.Template, data interface{}) error {
+	if r.Header != "" {
+		if _, err := fmt.Fprintln(w, r.Header); err != nil {
+			return err
+		}
+	}
+
+	if err := tpl.Execute(w, data); err != nil {
+		return err
+	}
+
+	if r.Footer != "" {
+		if _, err := fmt.Fprintln(w, r.Footer); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func helpTemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"pad": func(value string, width int) string {
+			return padText(value, width)
+		},
+		"columns": formatColumns,
+		"wrap":    wrapText,
+		"join":    strings.Join,
+		"upper":   strings.ToUpper,
+		"lower":   strings.ToLower,
+	}
+}
+
+var defaultHelpRenderer HelpRenderer
+
+func init() {
+	renderer, err := NewTemplateHelpRenderer("", "")
+	if err != nil {
+		panic(err)
+	}
+
+	defaultHelpRenderer = renderer
+}
+
+// wrapText wraps every line of text to fit into width columns.
+// The first line is expected to start at column indent, all the
+// other lines are prefixed with indent spaces (hanging indent).
+// Leading whitespace of a line is kept on its continuation lines.
+// Zero or negative width means no wrapping.
+func wrapText(width, indent int, text string) string {
+	if width <= 0 {
+		return text
+	}
+
+	var builder strings.Builder
+	prefix := strings.Repeat(" ", indent)
+
+	for index, line := range strings.Split(text, "\n") {
+		if index > 0 {
+			builder.WriteString("\n" + prefix)
+		}
+
+		lead := line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+		builder.WriteString(lead)
+		column := indent + visibleLength(lead)
+
+		for wordIndex, word := range strings.Fields(line) {
+			wordLength := visibleLength(word)
+			if wordIndex > 0 {
+				if column+1+wordLength > width {
+					builder.WriteString("\n" + prefix + lead)
+					column = indent + visibleLength(lead)
+				} else {
+					builder.WriteString(" ")
+					column++
+				}
+			}
+
+			builder.WriteString(word)
+			column += wordLength
+		}
+	}
+
+	return builder.String()
+}
+
+// formatColumns renders left padded to leftWidth and right wrapped
+// with a hanging indent next to it. If left is wider than leftWidth,
+// right starts in a new line.
+func formatColumns(left, right string, leftWidth, width int) string {
+	const gap = 3
+
+	if right == "" {
+		return left
+	}
+
+	indent := leftWidth + gap
+	if visibleLength(left) > leftWidth {
+		return left + "\n" + strings.Repeat(" ", indent) + wrapText(width, indent, right)
+	}
+
+	return padText(left, leftWidth) + strings.Repeat(" ", gap) + wrapText(width, indent, right)
+}
+
+// padText pads text with spaces to width, ANSI sequences are
+// not counted in the length of text
+func padText(text string, width int) string {
+	if length := visibleLength(text); length < width {
+		return text + strings.Repeat(" ", width-length)
+	}
+
+	return text
+}
@@ -0,0 +1,291 @@
+package commander
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"os"
+	"strings"
+	"testing"
+)
+
+type myHelpRenderer struct{}
+
+func (r *myHelpRenderer) RenderHelp(w io.Writer, data *HelpData) error {
+	_, err := io.WriteString(w, "custom help\n")
+	return err
+}
+
+func (r *myHelpRenderer) RenderCommandHelp(w io.Writer, data *CommandHelpData) error {
+	return errors.New("no command help")
+}
+
+func TestTemplateHelpRenderer_RenderHelp(t *testing.T) {
+	data := &HelpData{
+		ExecutableName: "my-executable",
+		SignatureWidth: 14,
+		Commands: []HelpCommand{
+			{Name: "a", Signature: "a <file>", ShortDescription: "Command A"},
+			{Name: "help", Signature: "help [command]", ShortDescription: "Help"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		template string
+		header   string
+		footer   string
+		want     string
+	}{
+		{
+			name: "Default template",
+			want: "a <file>         Command A\nhelp [command]   Help\n",
+		},
+		{
+			name:     "Custom template",
+			template: "{{range .Commands}}{{upper .Name}};{{end}}",
+			want:     "A;HELP;",
+		},
+		{
+			name:     "Header and Footer",
+			template: "{{.ExecutableName}}\n",
+			header:   "== Banner ==",
+			footer:   "== Footer ==",
+			want:     "== Banner ==\nmy-executable\n== Footer ==\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewTemplateHelpRenderer(tt.template, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			r.Header = tt.header
+			r.Footer = tt.footer
+
+			var output bytes.Buffer
+			if err := r.RenderHelp(&output, data); err != nil {
+				t.Fatal(err)
+			}
+
+			if got := output.String(); got != tt.want {
+				t.Errorf("TemplateHelpRenderer.RenderHelp() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateHelpRenderer_RenderCommandHelp(t *testing.T) {
+	r, _ := NewTemplateHelpRenderer("", "")
+
+	var output bytes.Buffer
+	err := r.RenderCommandHelp(&output, &CommandHelpData{
+		ExecutableName:  "my-executable",
+		Prefix:          "parent ",
+		Name:            "my-command",
+		Arguments:       "<file>",
+		LongDescription: "Long description.",
+		OptionGroups: []HelpOptionGroup{
+			{
+				Options: []HelpOption{
+					{Signature: "--list=StringArray[] [optional]", Summary: "List of things"},
+					{Signature: "--owner=MyType <required>"},
+				},
+			},
+			{
+				Name: "Output",
+				Options: []HelpOption{
+					{Signature: "--output=FILE [optional]", Summary: "Output file"},
+				},
+			},
+		},
+		OptionWidth: 33,
+		Examples:    []string{"test.txt"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := `Usage: my-executable parent my-command <file>
+
+Long description.
+
+Options:
+  --list=StringArray[] [optional]   List of things
+  --owner=MyType <required>
+
+Output:
+  --output=FILE [optional]          Output file
+
+Examples:
+  my-executable parent my-command test.txt
+`
+	if got := output.String(); got != want {
+		t.Errorf("TemplateHelpRenderer.RenderCommandHelp() = %q, want %q", got, want)
+	}
+}
+
+func TestNewTemplateHelpRenderer_invalid(t *testing.T) {
+	if _, err := NewTemplateHelpRenderer("{{.Commands", ""); err == nil {
+		t.Error("NewTemplateHelpRenderer() should fail on invalid template")
+	}
+}
+
+func TestCommandRegistry_HelpRenderer(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"/some/random/path/my-executable", "help"}
+	c := NewCommandRegistry()
+	c.HelpRenderer = &myHelpRenderer{}
+	c.Register(func(appName string) *CommandWrapper {
+		return &CommandWrapper{
+			Handler: &MyCommand{},
+			Help:    &CommandDescriptor{Name: "my-command"},
+		}
+	})
+
+	mockOutput = ""
+	c.Help()
+	if mockOutput != "custom help\n" {
+		t.Errorf("output(%s), want(%s)", mockOutput, "custom help\n")
+	}
+
+	mockOutput = ""
+	c.CommandHelp("my-command")
+	if !strings.Contains(mockOutput, "no command help") {
+		t.Errorf("value(%s) not found in output(%s)", "no command help", mockOutput)
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	tests := []struct {
+		name   string
+		width  int
+		indent int
+		text   string
+		want   string
+	}{
+		{
+			name:  "No width",
+			width: 0,
+			text:  "This is a very long\ndescription about this command.",
+			want:  "This is a very long\ndescription about this command.",
+		},
+		{
+			name:  "Wrap long line",
+			width: 20,
+			text:  "This is a very long description about this command.",
+			want:  "This is a very long\ndescription about\nthis command.",
+		},
+		{
+			name:   "Hanging indent",
+			width:  20,
+			indent: 6,
+			text:   "This is a very long description.",
+			want:   "This is a very\n      long\n      description.",
+		},
+		{
+			name:  "Keep line breaks and leading whitespace",
+			width: 16,
+			text:  "List:\n  - first item in list",
+			want:  "List:\n  - first item\n  in list",
+		},
+		{
+			name:  "Word longer than width",
+			width: 5,
+			text:  "a verylongword b",
+			want:  "a\nverylongword\nb",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := wrapText(tt.width, tt.indent, tt.text); got != tt.want {
+				t.Errorf("wrapText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatColumns(t *testing.T) {
+	tests := []struct {
+		name      string
+		left      string
+		right     string
+		leftWidth int
+		width     int
+		want      string
+	}{
+		{
+			name:      "Fits",
+			left:      "cmd <a>",
+			right:     "Description",
+			leftWidth: 10,
+			want:      "cmd <a>      Description",
+		},
+		{
+			name:      "Wrapped description",
+			left:      "cmd",
+			right:     "A long description",
+			leftWidth: 4,
+			width:     16,
+			want:      "cmd    A long\n       description",
+		},
+		{
+			name:      "Left column too wide",
+			left:      "command <with> <many> [arguments]",
+			right:     "Description",
+			leftWidth: 10,
+			width:     40,
+			want:      "command <with> <many> [arguments]\n             Description",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := formatColumns(tt.left, tt.right, tt.leftWidth, tt.width); got != tt.want {
+				t.Errorf("formatColumns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommandRegistry_CommandHelp_options(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"/some/random/path/my-executable", "help", "my-command"}
+	c := NewCommandRegistry()
+	c.Register(func(appName string) *CommandWrapper {
+		return &CommandWrapper{
+			Handler: &MyCommand{},
+			Arguments: []*Argument{
+				&Argument{Name: "output", Type: "FilePath", Placeholder: "FILE", Group: "Output", Description: "Output file"},
+				&Argument{Name: "count", Type: "Int64", FailOnError: true, Description: "Number of items"},
+				&Argument{Name: "cache", Type: "ByteSize", Default: "1536MiB", Max: "2GiB"},
+				&Argument{Name: "format", Type: "String", Group: "Output", Choices: []string{"json", "yaml"}},
+			},
+			Help: &CommandDescriptor{Name: "my-command"},
+		}
+	})
+
+	mockOutput = ""
+	c.Execute()
+
+	want := `Usage: my-executable my-command 
+
+Output:
+  --output=FILE [optional]        Output file
+  --format=json|yaml [optional]
+
+Options:
+  --count=Int64 <required>        Number of items
+  --cache=ByteSize [optional]     (default: 1.5 GiB, range: ..2 GiB)
+`
+	if mockOutput != want {
+		t.Errorf("output(%q), want(%q)", mockOutput, want)
+	}
+}
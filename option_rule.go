@@ -0,0 +1,144 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+)
+
+// RuleKind is the kind of an OptionRule
+type RuleKind int
+
+const (
+	// ConflictRule allows at most one of the options
+	ConflictRule RuleKind = iota
+	// RequireRule requires all the other options if the first one is passed
+	RequireRule
+	// ExactlyOneRule requires exactly one of the options
+	ExactlyOneRule
+	// AtLeastOneRule requires at least one of the options
+	AtLeastOneRule
+)
+
+// OptionRule is a relationship between options (and flags),
+// checked by CommandRegistry.Execute before the Validator
+type OptionRule struct {
+	Kind    RuleKind
+	Options []string
+}
+
+// Conflicts creates a rule where the options cannot be used together
+func Conflicts(options ...string) OptionRule {
+	return OptionRule{Kind: ConflictRule, Options: options}
+}
+
+// Requires creates a rule where option requires all the other options
+func Requires(option string, required ...string) OptionRule {
+	return OptionRule{Kind: RequireRule, Options: append([]string{option}, required...)}
+}
+
+// ExactlyOne creates a rule where exactly one of the options is required
+func ExactlyOne(options ...string) OptionRule {
+	return OptionRule{Kind: ExactlyOneRule, Options: options}
+}
+
+// AtLeastOne creates a rule where at least one of the options is required
+func AtLeastOne(options ...string) OptionRule {
+	return OptionRule{Kind: AtLeastOneRule, Options: options}
+}
+
+// String returns with the description of the rule
+func (r OptionRule) String() string {
+	names := optionNames(r.Options)
+
+	switch r.Kind {
+	case ConflictRule:
+		return fmt.Sprintf("%s cannot be used together", joinNames(names, "and"))
+	case RequireRule:
+		if len(names) < 2 {
+			return ""
+		}
+		return fmt.Sprintf("%s requires %s", names[0], joinNames(names[1:], "and"))
+	case ExactlyOneRule:
+		return fmt.Sprintf("exactly one of %s is required", joinNames(names, "or"))
+	case AtLeastOneRule:
+		return fmt.Sprintf("at least one of %s is required", joinNames(names, "or"))
+	}
+
+	return ""
+}
+
+// Check returns with an error if the rule is violated
+func (r OptionRule) Check(c *CommandHelper) error {
+	passed := []string{}
+	missing := []string{}
+	for _, option := range r.Options {
+		if c.isPassed(option) {
+			passed = append(passed, option)
+		} else {
+			missing = append(missing, option)
+		}
+	}
+
+	switch r.Kind {
+	case ConflictRule:
+		if len(passed) > 1 {
+			return fmt.Errorf("%s cannot be used together", joinNames(optionNames(passed), "and"))
+		}
+	case RequireRule:
+		if len(r.Options) > 1 && c.isPassed(r.Options[0]) && len(missing) > 0 {
+			return fmt.Errorf("%s requires %s", optionName(r.Options[0]), joinNames(optionNames(missing), "and"))
+		}
+	case ExactlyOneRule:
+		if len(passed) > 1 {
+			return fmt.Errorf("only one of %s can be used", joinNames(optionNames(passed), "and"))
+		}
+		if len(passed) < 1 {
+			return fmt.Errorf("one of %s is required", joinNames(optionNames(r.Options), "or"))
+		}
+	case AtLeastOneRule:
+		if len(passed) < 1 {
+			return fmt.Errorf("at least one of %s is required", joinNames(optionNames(r.Options), "or"))
+		}
+	}
+
+	return nil
+}
+
+// checkRules checks all the rules and returns with all the violations
+func checkRules(rules []OptionRule, c *CommandHelper) []error {
+	errs := []error{}
+	for _, rule := range rules {
+		if err := rule.Check(c); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// optionName returns with the option as it's used: -f or --force
+func optionName(name string) string {
+	if len(name) == 1 {
+		return "-" + name
+	}
+
+	return "--" + name
+}
+
+func optionNames(names []string) []string {
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		result = append(result, optionName(name))
+	}
+
+	return result
+}
+
+// joinNames joins names in a sentence: --a, --b and --c
+func joinNames(names []string, conjunction string) string {
+	if len(names) < 2 {
+		return strings.Join(names, "")
+	}
+
+	return strings.Join(names[:len(names)-1], ", ") + " " + conjunction + " " + names[len(names)-1]
+}
@@ -0,0 +1,143 @@
+package commander
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestOptionRule_Check(t *testing.T) {
+	tests := []struct {
+		name    string
+		rule    OptionRule
+		flag    []string
+		wantErr string
+	}{
+		{name: "Conflicts ok", rule: Conflicts("all", "name"), flag: []string{"cmd", "--all"}},
+		{name: "Conflicts", rule: Conflicts("all", "name"), flag: []string{"cmd", "--all", "--name=x"}, wantErr: "--all and --name cannot be used together"},
+		{name: "Conflicts short flag", rule: Conflicts("a", "b", "c"), flag: []string{"cmd", "-abc"}, wantErr: "-a, -b and -c cannot be used together"},
+		{name: "Requires ok", rule: Requires("user", "password"), flag: []string{"cmd", "--user=x", "--password=y"}},
+		{name: "Requires not used", rule: Requires("user", "password"), flag: []string{"cmd", "--password=y"}},
+		{name: "Requires", rule: Requires("user", "password", "host"), flag: []string{"cmd", "--user=x"}, wantErr: "--user requires --password and --host"},
+		{name: "ExactlyOne ok", rule: ExactlyOne("id", "email"), flag: []string{"cmd", "--id=1"}},
+		{name: "ExactlyOne none", rule: ExactlyOne("id", "email"), flag: []string{"cmd"}, wantErr: "one of --id or --email is required"},
+		{name: "ExactlyOne both", rule: ExactlyOne("id", "email"), flag: []string{"cmd", "--id=1", "--email=a@b"}, wantErr: "only one of --id and --email can be used"},
+		{name: "AtLeastOne ok", rule: AtLeastOne("id", "email"), flag: []string{"cmd", "--id=1", "--email=a@b"}},
+		{name: "AtLeastOne", rule: AtLeastOne("id", "email"), flag: []string{"cmd"}, wantErr: "at least one of --id or --email is required"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CommandHelper{}
+			c.Parse(tt.flag)
+			err := tt.rule.Check(c)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("OptionRule.Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptionRule_Check_sources(t *testing.T) {
+	os.Setenv("TEST_COMMANDER_ID", "42")
+	defer os.Unsetenv("TEST_COMMANDER_ID")
+
+	tests := []struct {
+		name      string
+		arguments []*Argument
+		config    ConfigSource
+		flag      []string
+		wantErr   string
+	}{
+		{
+			name:      "Env",
+			arguments: []*Argument{{Name: "id", Type: "Int64", Env: "TEST_COMMANDER_ID"}, {Name: "email", Type: "String"}},
+		},
+		{
+			name:      "Config",
+			arguments: []*Argument{{Name: "id", Type: "Int64"}, {Name: "email", Type: "String"}},
+			config:    mapConfig{"email": "a@b"},
+		},
+		{
+			name:      "Env and command line",
+			arguments: []*Argument{{Name: "id", Type: "Int64", Env: "TEST_COMMANDER_ID"}, {Name: "email", Type: "String"}},
+			flag:      []string{"--email=a@b"},
+			wantErr:   "only one of --id and --email can be used",
+		},
+		{
+			name:      "Default is not passed",
+			arguments: []*Argument{{Name: "id", Type: "Int64", Default: "1"}, {Name: "email", Type: "String"}},
+			wantErr:   "one of --id or --email is required",
+		},
+		{
+			name:      "Disabled Bool argument",
+			arguments: []*Argument{{Name: "id", Type: "Bool"}, {Name: "email", Type: "String"}},
+			flag:      []string{"--id=false"},
+			wantErr:   "one of --id or --email is required",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CommandHelper{Config: tt.config}
+			c.AttachArgumentList(tt.arguments)
+			c.Parse(append([]string{"cmd"}, tt.flag...))
+			err := ExactlyOne("id", "email").Check(c)
+			if (err != nil || tt.wantErr != "") && (err == nil || err.Error() != tt.wantErr) {
+				t.Errorf("OptionRule.Check() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOptionRule_String(t *testing.T) {
+	tests := []struct {
+		rule OptionRule
+		want string
+	}{
+		{Conflicts("all", "name"), "--all and --name cannot be used together"},
+		{Requires("user", "password"), "--user requires --password"},
+		{ExactlyOne("id", "email", "n"), "exactly one of --id, --email or -n is required"},
+		{AtLeastOne("id", "email"), "at least one of --id or --email is required"},
+	}
+	for _, tt := range tests {
+		if got := tt.rule.String(); got != tt.want {
+			t.Errorf("OptionRule.String() = %v, want %v", got, tt.want)
+		}
+	}
+}
+
+func TestCommandRegistry_Rules(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"/some/random/path/my-executable", "my-command", "-v", "--all", "--name=x", "--user=me"}
+	executeCalled = false
+	c := NewCommandRegistry()
+	c.Register(func(appName string) *CommandWrapper {
+		return &CommandWrapper{
+			Handler: &MyCommand{},
+			Help:    &CommandDescriptor{Name: "my-command"},
+			Rules: []OptionRule{
+				Conflicts("all", "name"),
+				Requires("user", "password"),
+				ExactlyOne("id", "email"),
+			},
+		}
+	})
+	c.Execute()
+
+	if executeCalled {
+		t.Error("Command should not be called")
+	}
+
+	values := []string{
+		"[E] --all and --name cannot be used together\n[E] --user requires --password\n[E] one of --id or --email is required\n",
+		"Rules:\n  --all and --name cannot be used together\n  --user requires --password\n  exactly one of --id or --email is required\n",
+	}
+	for _, value := range values {
+		if !strings.Contains(mockOutput, value) {
+			t.Errorf("value(%s) not found in output(%s)", value, mockOutput)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// DefaultPager is used if PAGER environment variable is not defined
+const DefaultPager = "less -R"
+
+// ErrPagerNotStarted is returned (wrapped) by RunPager if the pager
+// could not be started, the help is printed without pager in this case
+var ErrPagerNotStarted = errors.New("pager not started")
+
+// RunPager sends content through the given pager command
+var RunPager = runPager
+
+func runPager(pager, content string) error {
+	parts := strings.Fields(pager)
+	if len(parts) < 1 {
+		return fmt.Errorf("%w: empty pager command", ErrPagerNotStarted)
+	}
+
+	cmd := exec.Command(parts[0], parts[1:]...)
+	cmd.Stdin = strings.NewReader(content)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("%w: %s", ErrPagerNotStarted, err)
+	}
+
+	return cmd.Wait()
+}
+
+// pagerCommand returns the pager defined in PAGER environment variable
+// or DefaultPager
+func pagerCommand() string {
+	if pager := strings.TrimSpace(os.Getenv("PAGER")); pager != "" {
+		return pager
+	}
+
+	return DefaultPager
+}
+
+// needsPager reports whether content is taller than the terminal
+func needsPager(content string) bool {
+	if !OutputIsTerminal() {
+		return false
+	}
+
+	_, height, err := TerminalSize()
+	if err != nil || height < 1 {
+		return false
+	}
+
+	return strings.Count(content, "\n") > height
+}
@@ -0,0 +1,102 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"testing"
+)
+
+func TestCommandRegistry_pager(t *testing.T) {
+	tests := []struct {
+		name         string
+		cliArgs      []string
+		terminal     bool
+		height       int
+		disablePager bool
+		pagerErr     error
+		paged        bool
+	}{
+		{name: "Long help", cliArgs: []string{"help"}, terminal: true, height: 5, paged: true},
+		{name: "Short help", cliArgs: []string{"help"}, terminal: true, height: 50, paged: false},
+		{name: "Not a terminal", cliArgs: []string{"help"}, terminal: false, height: 5, paged: false},
+		{name: "Disabled", cliArgs: []string{"help"}, terminal: true, height: 5, disablePager: true, paged: false},
+		{name: "--no-pager", cliArgs: []string{"help", "--no-pager"}, terminal: true, height: 5, paged: false},
+		{name: "Pager not started", cliArgs: []string{"help"}, terminal: true, height: 5, pagerErr: fmt.Errorf("%w: not found", ErrPagerNotStarted), paged: false},
+		{name: "Pager exit status", cliArgs: []string{"help"}, terminal: true, height: 5, pagerErr: errors.New("exit status 1"), paged: true},
+	}
+
+	oldArgs := os.Args
+	oldPager, oldSize := RunPager, TerminalSize
+	defer func() {
+		os.Args = oldArgs
+		RunPager, TerminalSize = oldPager, oldSize
+		os.Unsetenv("PAGER")
+		mockEverything()
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEverything()
+			os.Setenv("NO_COLOR", "1")
+			os.Setenv("PAGER", "more")
+			OutputIsTerminal = func() bool { return tt.terminal }
+			TerminalSize = func() (int, int, error) { return 0, tt.height, nil }
+
+			pagedContent := ""
+			RunPager = func(pager, content string) error {
+				if pager != "more" {
+					t.Errorf("pager = %s, want more", pager)
+				}
+				if !errors.Is(tt.pagerErr, ErrPagerNotStarted) {
+					pagedContent = content
+				}
+				return tt.pagerErr
+			}
+
+			os.Args = append([]string{"/some/random/path/my-executable"}, tt.cliArgs...)
+			c := NewCommandRegistry()
+			c.DisablePager = tt.disablePager
+			for i := 0; i < 10; i++ {
+				name := fmt.Sprintf("command-%d", i)
+				c.Register(func(appName string) *CommandWrapper {
+					return &CommandWrapper{
+						Handler: &MyCommand{},
+						Help:    &CommandDescriptor{Name: name},
+					}
+				})
+			}
+			c.Execute()
+
+			if paged := pagedContent != ""; paged != tt.paged {
+				t.Errorf("paged = %v, want %v", paged, tt.paged)
+			}
+
+			if tt.paged == (mockOutput != "") {
+				t.Errorf("help should be printed exactly once, output(%s)", mockOutput)
+			}
+		})
+	}
+}
+
+func TestPagerCommand(t *testing.T) {
+	defer os.Unsetenv("PAGER")
+
+	os.Unsetenv("PAGER")
+	if got := pagerCommand(); got != DefaultPager {
+		t.Errorf("pagerCommand() = %s, want %s", got, DefaultPager)
+	}
+
+	os.Setenv("PAGER", "most")
+	if got := pagerCommand(); got != "most" {
+		t.Errorf("pagerCommand() = %s, want most", got)
+	}
+}
+
+func TestRunPager_notStarted(t *testing.T) {
+	for _, pager := range []string{"", "commander-no-such-pager"} {
+		if err := runPager(pager, "content"); !errors.Is(err, ErrPagerNotStarted) {
+			t.Errorf("runPager(%q) = %v, want ErrPagerNotStarted", pager, err)
+		}
+	}
+}
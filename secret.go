@@ -0,0 +1,155 @@
+package commander
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+)
+
+// SecretMask replaces the values of Secret arguments
+// in all output generated by the library
+const SecretMask = "********"
+
+// secretFileSuffix is the suffix of the option reading a Secret
+// argument from a file: --token-file=path for --token
+const secretFileSuffix = "-file"
+
+// SecretValue holds the value of a Secret argument. It's printed
+// as SecretMask with any fmt verb, use Reveal to get the value.
+type SecretValue struct {
+	value interface{}
+}
+
+// Reveal returns with the value of the Secret argument
+func (s SecretValue) Reveal() interface{} {
+	return s.value
+}
+
+func (s SecretValue) String() string {
+	return SecretMask
+}
+
+// Format prints SecretMask for all verbs
+func (s SecretValue) Format(f fmt.State, verb rune) {
+	io.WriteString(f, SecretMask)
+}
+
+// GetSecret returns with the typed value of a Secret argument,
+// it fails like Get, or with a *TypeMismatchError if the
+// argument is not Secret
+//
+//	token, err := commander.GetSecret[string](opts, "token")
+func GetSecret[T any](c *CommandHelper, key string) (T, error) {
+	var zero T
+
+	secret, err := Get[SecretValue](c, key)
+	if err != nil {
+		return zero, err
+	}
+
+	value, ok := secret.Reveal().(T)
+	if !ok {
+		return zero, &TypeMismatchError{
+			Name: key,
+			Type: c.argument(key).Type,
+			Got:  fmt.Sprintf("%T", secret.Reveal()),
+			Want: reflect.TypeOf((*T)(nil)).Elem().String(),
+		}
+	}
+
+	return value, nil
+}
+
+// exposedValue returns with the value of the argument,
+// wrapped into a SecretValue if the argument is Secret
+func (a *Argument) exposedValue() interface{} {
+	if a.Secret && a.Value != nil {
+		return SecretValue{value: a.Value}
+	}
+
+	return a.Value
+}
+
+// displayValue returns with the original value,
+// or with SecretMask if the argument is Secret
+func (a *Argument) displayValue() string {
+	if a.Secret && a.OriginalValue != "" {
+		return SecretMask
+	}
+
+	return a.OriginalValue
+}
+
+// secretError hides the message of an error of a Secret argument,
+// as it may quote the value. The original error is returned by Unwrap.
+type secretError struct {
+	err error
+}
+
+func (e *secretError) Error() string {
+	return "invalid secret value"
+}
+
+func (e *secretError) Unwrap() error {
+	return e.err
+}
+
+// exposedError returns with the error of the argument,
+// wrapped into a secretError if the argument is Secret
+func (a *Argument) exposedError() error {
+	if a.Secret && a.Error != nil {
+		return &secretError{err: a.Error}
+	}
+
+	return a.Error
+}
+
+// secretFileArgument returns with the Secret argument
+// read by the named option: "token" for "token-file"
+func (c *CommandHelper) secretFileArgument(name string) *Argument {
+	if !strings.HasSuffix(name, secretFileSuffix) {
+		return nil
+	}
+
+	arg := c.argument(strings.TrimSuffix(name, secretFileSuffix))
+	if arg == nil || !arg.Secret {
+		return nil
+	}
+
+	return arg
+}
+
+// readSecretFile returns with the content of the file without
+// the trailing line break, "-" is the standard input
+func (c *CommandHelper) readSecretFile(path string) (string, error) {
+	if path != StdinName {
+		content, err := readContent(path)
+
+		return strings.TrimRight(content, "\r\n"), err
+	}
+
+	content, err := c.resolveStdinValue(stdinContent{})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimRight(content.(string), "\r\n"), nil
+}
+
+// maskSecrets replaces the values of Secret arguments in Opts
+func (c *CommandHelper) maskSecrets() {
+	for _, arg := range c.argList {
+		if !arg.Secret {
+			continue
+		}
+
+		if _, ok := c.Opts[arg.Name]; ok {
+			c.Opts[arg.Name] = SecretMask
+		}
+
+		for index := range c.optList[arg.Name] {
+			c.optList[arg.Name][index] = SecretMask
+		}
+	}
+}
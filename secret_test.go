@@ -0,0 +1,148 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func newSecretHelper() *CommandHelper {
+	c := &CommandHelper{Stdin: strings.NewReader("from-stdin\n")}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "token", Type: "String", Secret: true, MinLength: 8},
+		&Argument{Name: "pin", Type: "Int64", Secret: true},
+		&Argument{Name: "pins", Type: "Int64[]", Secret: true},
+		&Argument{Name: "key", Type: "FilePath", Secret: true},
+	})
+
+	return c
+}
+
+func TestCommandHelper_Parse_secret(t *testing.T) {
+	mockEverything()
+
+	file := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(file, []byte("from-file-token\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		flag   []string
+		want   string
+		source string
+	}{
+		{name: "Option", flag: []string{"cmd", "--token=s3cr3t-token"}, want: "s3cr3t-token", source: "command line"},
+		{name: "File", flag: []string{"cmd", "--token-file", file}, want: "from-file-token", source: "command line (" + file + ")"},
+		{name: "Stdin", flag: []string{"cmd", "--token-file=-"}, want: "from-stdin", source: "command line (-)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := newSecretHelper()
+			c.Parse(tt.flag)
+
+			if got := c.Opt("token"); tt.name == "Option" && got != SecretMask {
+				t.Errorf("CommandHelper.Opt(token) = %q, want %q", got, SecretMask)
+			}
+
+			if got := fmt.Sprintf("%v %s %#v %d", c.TypedOpt("token"), c.TypedOpt("token"), c.TypedOpt("token"), c.TypedOpt("token")); strings.Contains(got, tt.want) {
+				t.Errorf("TypedOpt(token) is printed as %q", got)
+			}
+
+			if _, err := Get[string](c, "token"); err == nil {
+				t.Error("Get[string](token) should fail for a Secret argument")
+			}
+
+			if got, err := GetSecret[string](c, "token"); got != tt.want || err != nil {
+				t.Errorf("GetSecret[string](token) = %q, %v, want %q", got, err, tt.want)
+			}
+
+			want := "option --token is String (string), not fmt.Stringer"
+			if _, err := GetSecret[fmt.Stringer](c, "token"); err == nil || err.Error() != want {
+				t.Errorf("GetSecret[fmt.Stringer](token) error = %v, want %s", err, want)
+			}
+
+			if got := c.Source("token").String(); got != tt.source {
+				t.Errorf("CommandHelper.Source(token) = %q, want %q", got, tt.source)
+			}
+
+			if !c.isPassed("token") {
+				t.Error("CommandHelper.isPassed(token) should be true")
+			}
+		})
+	}
+}
+
+func TestCommandHelper_Parse_secretMasked(t *testing.T) {
+	mockEverything()
+
+	c := newSecretHelper()
+	c.DebugMode = true
+	mockOutput = ""
+	errs := c.parse([]string{"cmd", "--token=short", "--pin=12ab", "--pins=1234,abcd", "--key=~/no-such-key"})
+	c.logResolvedArguments()
+
+	output := mockOutput + errs.Error() + c.Warnings.Error()
+	for _, key := range []string{"token", "pin", "pins", "key"} {
+		output += "\n" + c.ErrorForTypedOpt(key).Error()
+	}
+
+	for _, value := range []string{"short", "12ab", "1234", "abcd", "no-such-key"} {
+		if strings.Contains(output, value) {
+			t.Errorf("secret value %q found in output(%s)", value, output)
+		}
+	}
+
+	if want := "Invalid argument: --pin=" + SecretMask + " [invalid secret value]"; !strings.Contains(output, want) {
+		t.Errorf("value(%s) not found in output(%s)", want, output)
+	}
+
+	_, err := Get[SecretValue](c, "pin")
+	var invalid *InvalidOptionError
+	if !errors.As(err, &invalid) || strings.Contains(err.Error(), "12ab") {
+		t.Errorf("Get[SecretValue](pin) error = %v, want masked InvalidOptionError", err)
+	}
+
+	var numError *strconv.NumError
+	if !errors.As(err, &numError) {
+		t.Errorf("Get[SecretValue](pin) error = %v, the original error should be wrapped", err)
+	}
+
+	if errs := newSecretHelper().parse([]string{"cmd", "--token-file=/no/such/file"}); len(errs) != 1 {
+		t.Errorf("unreadable --token-file should fail, got %v", errs)
+	}
+}
+
+func TestCommandRegistry_CommandHelp_secret(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"/some/random/path/my-executable", "help", "my-command"}
+	c := NewCommandRegistry()
+	c.Register(func(appName string) *CommandWrapper {
+		return &CommandWrapper{
+			Handler: &MyCommand{},
+			Arguments: []*Argument{
+				&Argument{Name: "token", Type: "String", Secret: true, Default: "dev-token", Description: "API token"},
+			},
+			Help: &CommandDescriptor{Name: "my-command"},
+		}
+	})
+
+	mockOutput = ""
+	c.Execute()
+
+	want := `Options:
+  --token=String [optional]      API token (default: ` + SecretMask + `)
+  --token-file=FILE [optional]   Read --token from FILE, - for standard input
+`
+	if !strings.Contains(mockOutput, want) {
+		t.Errorf("value(%q) not found in output(%q)", want, mockOutput)
+	}
+}
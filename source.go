@@ -0,0 +1,81 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// SourceKind is the kind of place a value is coming from
+type SourceKind int
+
+const (
+	// SourceNone means the argument has no value
+	SourceNone SourceKind = iota
+	// SourceCommandLine is an option or flag on the command line
+	SourceCommandLine
+	// SourceEnv is an environment variable (Argument.Env)
+	SourceEnv
+	// SourceConfig is a key in a configuration file (ConfigSource)
+	SourceConfig
+	// SourceDefault is the Default of the Argument
+	SourceDefault
+)
+
+// Source describes where the value of an Argument is coming from
+type Source struct {
+	Kind SourceKind
+	// Env is the name of the environment variable for SourceEnv
+	Env string
+	// Path of the configuration file for SourceConfig,
+	// or the file of a Secret argument (--name-file) for SourceCommandLine
+	Path string
+	// Key in the configuration file for SourceConfig
+	Key string
+}
+
+func (s Source) String() string {
+	switch s.Kind {
+	case SourceCommandLine:
+		if s.Path != "" {
+			return "command line (" + s.Path + ")"
+		}
+		return "command line"
+	case SourceEnv:
+		return "env " + s.Env
+	case SourceConfig:
+		return fmt.Sprintf("config %s (%s)", s.Path, s.Key)
+	case SourceDefault:
+		return "default"
+	}
+
+	return "not set"
+}
+
+// ConfigSource provides values from configuration files. Values are
+// looked up after the command line and environment variables,
+// before the Default of the Argument.
+type ConfigSource interface {
+	// Lookup returns with the value of the named option,
+	// the path of the file and the key of the value in it
+	Lookup(name string) (value, path, key string, found bool)
+}
+
+// logResolvedArguments logs the value and the source of all arguments
+func (c *CommandHelper) logResolvedArguments() {
+	if !c.DebugMode || len(c.argList) < 1 {
+		return
+	}
+
+	var table strings.Builder
+	writer := tabwriter.NewWriter(&table, 0, 0, 2, ' ', 0)
+	for _, arg := range c.argList {
+		fmt.Fprintf(writer, "  --%s\t%s\t%s\n", arg.Name, arg.displayValue(), arg.Source)
+	}
+	writer.Flush()
+
+	c.Log("Resolved configuration:")
+	for _, line := range strings.Split(strings.TrimSuffix(table.String(), "\n"), "\n") {
+		c.Log(line)
+	}
+}
@@ -0,0 +1,61 @@
+package commander
+
+import (
+	"os"
+	"testing"
+)
+
+type mapConfig map[string]string
+
+func (c mapConfig) Lookup(name string) (string, string, string, bool) {
+	value, found := c[name]
+	return value, "/etc/my-executable.conf", "options." + name, found
+}
+
+func TestCommandHelper_Source(t *testing.T) {
+	mockEverything()
+
+	os.Setenv("TEST_COMMANDER_NAME", "from-env")
+	defer os.Unsetenv("TEST_COMMANDER_NAME")
+
+	c := &CommandHelper{Config: mapConfig{"name": "from-config", "size": "1k"}}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "count", Type: "Int64", Default: "3"},
+		&Argument{Name: "name", Type: "String", Env: "TEST_COMMANDER_NAME"},
+		&Argument{Name: "size", Type: "ByteSize", Env: "TEST_COMMANDER_SIZE"},
+		&Argument{Name: "label", Type: "String", Default: "x"},
+		&Argument{Name: "output", Type: "String"},
+		&Argument{Name: "force", Type: "Bool"},
+	})
+
+	mockOutput = ""
+	c.Parse([]string{"cmd", "-d", "--label=y", "--force"})
+
+	tests := map[string]string{
+		"count":  "default",
+		"name":   "env TEST_COMMANDER_NAME",
+		"size":   "config /etc/my-executable.conf (options.size)",
+		"label":  "command line",
+		"output": "not set",
+		"force":  "command line",
+		"no-key": "not set",
+	}
+	for key, want := range tests {
+		if got := c.Source(key).String(); got != want {
+			t.Errorf("CommandHelper.Source(%s) = %q, want %q", key, got, want)
+		}
+	}
+
+	c.logResolvedArguments()
+	want := `[Debug] Resolved configuration:
+[Debug]   --count   3         default
+[Debug]   --name    from-env  env TEST_COMMANDER_NAME
+[Debug]   --size    1k        config /etc/my-executable.conf (options.size)
+[Debug]   --label   y         command line
+[Debug]   --output            not set
+[Debug]   --force   true      command line
+`
+	if mockOutput != want {
+		t.Errorf("output(%q), want(%q)", mockOutput, want)
+	}
+}
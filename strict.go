@@ -0,0 +1,142 @@
+package commander
+
+import (
+	"fmt"
+	"sort"
+)
+
+// builtinFlags are accepted in strict mode without declaration
+var builtinFlags = []string{"d", "v", "no-color", "no-pager"}
+
+// UnknownOptionError is an option or flag not declared
+// on the command in strict mode
+type UnknownOptionError struct {
+	// Name of the option without dashes
+	Name string
+	// Suggestion is the most similar declared option, if any
+	Suggestion string
+}
+
+func (e *UnknownOptionError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("Unknown option: %s (did you mean %s?)", optionName(e.Name), optionName(e.Suggestion))
+	}
+
+	return fmt.Sprintf("Unknown option: %s", optionName(e.Name))
+}
+
+// checkStrict returns with all the options and flags
+// that are not declared on the command
+func checkStrict(command *CommandWrapper, c *CommandHelper) ValidationErrors {
+	arguments := map[string]*Argument{}
+	known := []string{}
+	for _, arg := range command.Arguments {
+		arguments[arg.Name] = arg
+		known = append(known, arg.Name)
+
+		if arg.Secret {
+			name := arg.Name + secretFileSuffix
+			arguments[name] = &Argument{Name: name, Type: "FILE"}
+			known = append(known, name)
+		}
+	}
+
+	flags := map[string]bool{}
+	for _, flag := range command.Flags {
+		flags[flag.Name] = true
+		known = append(known, flag.Name)
+	}
+
+	for _, name := range builtinFlags {
+		flags[name] = true
+	}
+
+	errs := ValidationErrors{}
+
+	options := []string{}
+	for name := range c.Opts {
+		options = append(options, name)
+	}
+	sort.Strings(options)
+
+	for _, name := range options {
+		if _, ok := arguments[name]; !ok {
+			errs.Add(&UnknownOptionError{Name: name, Suggestion: suggest(name, known)})
+		}
+	}
+
+	passedFlags := []string{}
+	for name := range c.Flags {
+		passedFlags = append(passedFlags, name)
+	}
+	sort.Strings(passedFlags)
+
+	for _, name := range passedFlags {
+		if flags[name] {
+			continue
+		}
+
+		if arg, ok := arguments[name]; ok {
+			if arg.Type != "Bool" {
+				errs.Add(fmt.Errorf("Missing value: %s=%s", optionName(name), arg.Type))
+			}
+			continue
+		}
+
+		errs.Add(&UnknownOptionError{Name: name, Suggestion: suggest(name, known)})
+	}
+
+	return errs
+}
+
+// suggest returns with the most similar candidate to name,
+// or an empty string if none of them is similar enough
+func suggest(name string, candidates []string) string {
+	best := ""
+	bestDistance := len(name)/3 + 1
+	if bestDistance > 3 {
+		bestDistance = 3
+	}
+
+	for _, candidate := range candidates {
+		if distance := levenshtein(name, candidate); distance <= bestDistance && (best == "" || distance < levenshtein(name, best)) {
+			best = candidate
+		}
+	}
+
+	return best
+}
+
+// levenshtein returns with the edit distance of a and b
+func levenshtein(a, b string) int {
+	first, second := []rune(a), []rune(b)
+	previous := make([]int, len(second)+1)
+	current := make([]int, len(second)+1)
+
+	for j := range previous {
+		previous[j] = j
+	}
+
+	for i := 1; i <= len(first); i++ {
+		current[0] = i
+		for j := 1; j <= len(second); j++ {
+			cost := 1
+			if first[i-1] == second[j-1] {
+				cost = 0
+			}
+
+			current[j] = minInt(previous[j]+1, minInt(current[j-1]+1, previous[j-1]+cost))
+		}
+		previous, current = current, previous
+	}
+
+	return previous[len(second)]
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
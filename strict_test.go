@@ -0,0 +1,94 @@
+package commander
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCheckStrict(t *testing.T) {
+	command := &CommandWrapper{
+		Arguments: []*Argument{
+			&Argument{Name: "output", Type: "String"},
+			&Argument{Name: "enabled", Type: "Bool"},
+			&Argument{Name: "token", Type: "String", Secret: true},
+		},
+		Flags: []*Flag{
+			&Flag{Name: "force"},
+		},
+	}
+
+	tests := []struct {
+		name string
+		flag []string
+		want string
+	}{
+		{name: "Declared", flag: []string{"cmd", "--output=x", "--force", "--enabled", "-dv", "--no-color", "arg"}},
+		{name: "Typo in flag", flag: []string{"cmd", "--forse"}, want: "Unknown option: --forse (did you mean --force?)"},
+		{name: "Typo in option", flag: []string{"cmd", "--ouput=x"}, want: "Unknown option: --ouput (did you mean --output?)"},
+		{name: "No suggestion", flag: []string{"cmd", "--something=x", "-x"}, want: "Unknown option: --something\nUnknown option: -x"},
+		{name: "Missing value", flag: []string{"cmd", "--output"}, want: "Missing value: --output=String"},
+		{name: "Secret file", flag: []string{"cmd", "--token-file"}, want: "Missing value: --token-file=FILE"},
+		{name: "Typo in secret file", flag: []string{"cmd", "--token-fil=x"}, want: "Unknown option: --token-fil (did you mean --token-file?)"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &CommandHelper{}
+			c.AttachArgumentList(command.Arguments)
+			c.Parse(tt.flag)
+
+			if got := checkStrict(command, c).Error(); got != tt.want {
+				t.Errorf("checkStrict() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLevenshtein(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{"force", "force", 0},
+		{"forse", "force", 1},
+		{"", "abc", 3},
+		{"kitten", "sitting", 3},
+	}
+	for _, tt := range tests {
+		if got := levenshtein(tt.a, tt.b); got != tt.want {
+			t.Errorf("levenshtein(%s, %s) = %d, want %d", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestCommandRegistry_Strict(t *testing.T) {
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	for _, strictRegistry := range []bool{true, false} {
+		mockEverything()
+		executeCalled = false
+
+		os.Args = []string{"/some/random/path/my-executable", "my-command", "-v", "--forse"}
+		c := NewCommandRegistry()
+		c.Strict = strictRegistry
+		c.Register(func(appName string) *CommandWrapper {
+			return &CommandWrapper{
+				Handler: &MyCommand{},
+				Help:    &CommandDescriptor{Name: "my-command"},
+				Flags:   []*Flag{&Flag{Name: "force"}},
+				Strict:  !strictRegistry,
+			}
+		})
+		c.Execute()
+
+		if executeCalled {
+			t.Error("Command should not be called")
+		}
+
+		value := "[E] Unknown option: --forse (did you mean --force?)"
+		if !strings.Contains(mockOutput, value) {
+			t.Errorf("value(%s) not found in output(%s)", value, mockOutput)
+		}
+	}
+}
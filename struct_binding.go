@@ -0,0 +1,276 @@
+package commander
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// StructTag is the tag of fields bound to arguments:
+//
+//	Count int64 `cmd:"name=count,short=c,default=3,env=COUNT,required" help:"Number of items"`
+//
+// Keys: name, short, type, default, env, placeholder, group, min, max,
+// pattern, required, fail-on-error and secret. Values cannot contain
+// commas, the description is in the separate "help" tag.
+const StructTag = "cmd"
+
+// structFieldTypes maps field types to argument types
+var structFieldTypes = map[reflect.Type]string{
+	reflect.TypeOf(""):                  "String",
+	reflect.TypeOf(int(0)):              "Int64",
+	reflect.TypeOf(int8(0)):             "Int64",
+	reflect.TypeOf(int16(0)):            "Int64",
+	reflect.TypeOf(int32(0)):            "Int64",
+	reflect.TypeOf(int64(0)):            "Int64",
+	reflect.TypeOf(uint(0)):             "Uint64",
+	reflect.TypeOf(uint8(0)):            "Uint64",
+	reflect.TypeOf(uint16(0)):           "Uint64",
+	reflect.TypeOf(uint32(0)):           "Uint64",
+	reflect.TypeOf(uint64(0)):           "Uint64",
+	reflect.TypeOf(float32(0)):          "Float64",
+	reflect.TypeOf(float64(0)):          "Float64",
+	reflect.TypeOf(false):               "Bool",
+	reflect.TypeOf(time.Duration(0)):    "Duration",
+	reflect.TypeOf(time.Time{}):         "Time",
+	reflect.TypeOf([]string{}):          "StringArray[]",
+	reflect.TypeOf(map[string]string{}): "StringMap",
+	reflect.TypeOf(&url.URL{}):          "URL",
+	reflect.TypeOf(net.IP{}):            "IP",
+	reflect.TypeOf(&net.IPNet{}):        "CIDR",
+	reflect.TypeOf(HostPort{}):          "HostPort",
+	reflect.TypeOf(ByteSize(0)):         "ByteSize",
+	reflect.TypeOf(Percent(0)):          "Percent",
+	reflect.TypeOf(&Input{}):            "Input",
+}
+
+// ArgumentsFromStruct creates the Argument list from
+// the tagged fields of target (a pointer to a struct)
+func ArgumentsFromStruct(target interface{}) ([]*Argument, error) {
+	return argumentsFromStruct(target, sharedTypes)
+}
+
+func argumentsFromStruct(target interface{}, types *TypeRegistry) ([]*Argument, error) {
+	structValue, err := structPointer(target)
+	if err != nil {
+		return nil, err
+	}
+
+	arguments := []*Argument{}
+	structType := structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		tag, ok := field.Tag.Lookup(StructTag)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		if field.PkgPath != "" {
+			return nil, fmt.Errorf("field %s is not exported", field.Name)
+		}
+
+		arg, err := argumentFromField(field, tag, types)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %s", field.Name, err)
+		}
+
+		arguments = append(arguments, arg)
+	}
+
+	return arguments, nil
+}
+
+// BindArguments sets the tagged fields of target
+// (a pointer to a struct) from the parsed arguments.
+// Fields of arguments without value are not changed.
+func BindArguments(target interface{}, arguments []*Argument) error {
+	structValue, err := structPointer(target)
+	if err != nil {
+		return err
+	}
+
+	structType := structValue.Type()
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		tag, ok := field.Tag.Lookup(StructTag)
+		if !ok || tag == "-" {
+			continue
+		}
+
+		if field.PkgPath != "" {
+			return fmt.Errorf("field %s is not exported", field.Name)
+		}
+
+		name := fieldArgumentName(field, tag)
+		for _, arg := range arguments {
+			if arg.Name != name || arg.Value == nil || arg.Error != nil {
+				continue
+			}
+
+			value := reflect.ValueOf(arg.Value)
+			if !value.Type().ConvertibleTo(field.Type) {
+				return fmt.Errorf("field %s: cannot use %T as %s", field.Name, arg.Value, field.Type)
+			}
+
+			if overflows(structValue.Field(index), value) {
+				return fmt.Errorf("field %s: %v overflows %s", field.Name, arg.Value, field.Type)
+			}
+
+			structValue.Field(index).Set(value.Convert(field.Type))
+		}
+	}
+
+	return nil
+}
+
+func structPointer(target interface{}) (reflect.Value, error) {
+	value := reflect.ValueOf(target)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("expected a pointer to a struct, got %T", target)
+	}
+
+	return value.Elem(), nil
+}
+
+func argumentFromField(field reflect.StructField, tag string, types *TypeRegistry) (*Argument, error) {
+	arg := &Argument{
+		Name:        fieldArgumentName(field, tag),
+		Type:        structFieldTypes[field.Type],
+		Description: field.Tag.Get("help"),
+	}
+
+	for _, item := range strings.Split(tag, ",") {
+		parts := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		key, value := parts[0], ""
+		if len(parts) > 1 {
+			value = parts[1]
+		}
+
+		switch key {
+		case "", "name":
+		case "short":
+			arg.Short = value
+		case "type":
+			arg.Type = value
+		case "default":
+			arg.Default = value
+		case "env":
+			arg.Env = value
+		case "placeholder":
+			arg.Placeholder = value
+		case "group":
+			arg.Group = value
+		case "min":
+			arg.Min = value
+		case "max":
+			arg.Max = value
+		case "pattern":
+			arg.Pattern = value
+		case "required":
+			arg.Required = true
+		case "fail-on-error":
+			arg.FailOnError = true
+		case "secret":
+			arg.Secret = true
+		default:
+			return nil, fmt.Errorf("unknown key %q in tag", key)
+		}
+	}
+
+	if arg.Type == "" {
+		return nil, fmt.Errorf("no argument type for %s, use type=...", field.Type)
+	}
+
+	if !types.Has(arg.Type) {
+		return nil, fmt.Errorf("unknown argument type %s", arg.Type)
+	}
+
+	setFieldBounds(arg, field.Type)
+
+	return arg, nil
+}
+
+// setFieldBounds sets Min and Max (if not defined in the tag) to the
+// range of narrow numeric fields, out of range values fail in parse
+func setFieldBounds(arg *Argument, fieldType reflect.Type) {
+	kind := fieldType.Kind()
+	signed := arg.Type == "Int64" && kind >= reflect.Int && kind <= reflect.Int64
+	unsigned := arg.Type == "Uint64" && kind >= reflect.Uint && kind <= reflect.Uint64
+	float := arg.Type == "Float64" && kind == reflect.Float32
+	if (!signed && !unsigned && !float) || fieldType.Bits() == 64 {
+		return
+	}
+
+	bits := fieldType.Bits()
+	minimum, maximum := "", ""
+	switch {
+	case signed:
+		minimum = strconv.FormatInt(-1<<(bits-1), 10)
+		maximum = strconv.FormatInt(1<<(bits-1)-1, 10)
+	case unsigned:
+		maximum = strconv.FormatUint(1<<bits-1, 10)
+	case float:
+		minimum = strconv.FormatFloat(-math.MaxFloat32, 'g', -1, 64)
+		maximum = strconv.FormatFloat(math.MaxFloat32, 'g', -1, 64)
+	}
+
+	if arg.Min == "" {
+		arg.Min = minimum
+	}
+
+	if arg.Max == "" {
+		arg.Max = maximum
+	}
+}
+
+// overflows reports whether value cannot be stored in the numeric field
+func overflows(field, value reflect.Value) bool {
+	switch {
+	case field.CanInt() && value.CanInt():
+		return field.OverflowInt(value.Int())
+	case field.CanInt() && value.CanUint():
+		return value.Uint() > math.MaxInt64 || field.OverflowInt(int64(value.Uint()))
+	case field.CanUint() && value.CanUint():
+		return field.OverflowUint(value.Uint())
+	case field.CanUint() && value.CanInt():
+		return value.Int() < 0 || field.OverflowUint(uint64(value.Int()))
+	case field.CanFloat() && value.CanFloat():
+		return field.OverflowFloat(value.Float())
+	}
+
+	return false
+}
+
+// fieldArgumentName returns with the name from the tag, or with the
+// kebab-case field name: DryRun is dry-run, HTTPPort is http-port
+func fieldArgumentName(field reflect.StructField, tag string) string {
+	for _, item := range strings.Split(tag, ",") {
+		parts := strings.SplitN(strings.TrimSpace(item), "=", 2)
+		if len(parts) == 2 && parts[0] == "name" {
+			return parts[1]
+		}
+	}
+
+	runes := []rune(field.Name)
+	var name strings.Builder
+	for index, r := range runes {
+		// a word starts with an upper case letter after a lower case
+		// letter or digit, or with the last capital of an acronym
+		if index > 0 && unicode.IsUpper(r) {
+			previous := runes[index-1]
+			nextLower := index+1 < len(runes) && unicode.IsLower(runes[index+1])
+			if !unicode.IsUpper(previous) || nextLower {
+				name.WriteByte('-')
+			}
+		}
+		name.WriteRune(unicode.ToLower(r))
+	}
+
+	return name.String()
+}
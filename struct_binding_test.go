@@ -0,0 +1,225 @@
+package commander
+
+import (
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type structBindingOptions struct {
+	Count   int           `cmd:"name=count,short=c,default=3,env=TEST_COMMANDER_COUNT,required" help:"Number of items"`
+	Timeout time.Duration `cmd:"default=30s"`
+	DryRun  bool          `cmd:""`
+	Size    uint64        `cmd:"type=ByteSize"`
+	Labels  []string      `cmd:"name=label"`
+	Ignored string
+	Skipped string `cmd:"-"`
+}
+
+type structBindingCommand struct {
+	Options structBindingOptions
+	called  bool
+}
+
+func (c *structBindingCommand) Execute(opts *CommandHelper) {
+	c.called = true
+}
+
+func TestArgumentsFromStruct(t *testing.T) {
+	arguments, err := ArgumentsFromStruct(&structBindingOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Argument{
+		{Name: "count", Short: "c", Type: "Int64", Default: "3", Env: "TEST_COMMANDER_COUNT", Required: true, Description: "Number of items"},
+		{Name: "timeout", Type: "Duration", Default: "30s"},
+		{Name: "dry-run", Type: "Bool"},
+		{Name: "size", Type: "ByteSize"},
+		{Name: "label", Type: "StringArray[]"},
+	}
+	if len(arguments) != len(want) {
+		t.Fatalf("ArgumentsFromStruct() returned %d arguments, want %d", len(arguments), len(want))
+	}
+
+	for index, arg := range arguments {
+		w := want[index]
+		if arg.Name != w.Name || arg.Short != w.Short || arg.Type != w.Type || arg.Default != w.Default ||
+			arg.Env != w.Env || arg.Required != w.Required || arg.Description != w.Description {
+			t.Errorf("ArgumentsFromStruct()[%d] = %+v, want %+v", index, *arg, w)
+		}
+	}
+}
+
+func TestArgumentsFromStruct_invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		target interface{}
+		want   string
+	}{
+		{name: "Not a pointer", target: structBindingOptions{}, want: "expected a pointer to a struct"},
+		{name: "Unknown key", target: &struct {
+			A string `cmd:"colour=red"`
+		}{}, want: `field A: unknown key "colour" in tag`},
+		{name: "Unknown field type", target: &struct {
+			A complex128 `cmd:""`
+		}{}, want: "field A: no argument type for complex128"},
+		{name: "Unknown argument type", target: &struct {
+			A string `cmd:"type=Nope"`
+		}{}, want: "field A: unknown argument type Nope"},
+		{name: "Unexported field", target: &struct {
+			a string `cmd:""`
+		}{}, want: "field a is not exported"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ArgumentsFromStruct(tt.target)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("ArgumentsFromStruct() error = %v, want %s", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestBindArguments_unexportedField(t *testing.T) {
+	target := &struct {
+		name string `cmd:""`
+	}{}
+
+	arguments := []*Argument{&Argument{Name: "name", Type: "String", Value: "x"}}
+	if err := BindArguments(target, arguments); err == nil || err.Error() != "field name is not exported" {
+		t.Errorf("BindArguments() error = %v, want field name is not exported", err)
+	}
+}
+
+func TestCommandRegistry_Options(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	tests := []struct {
+		name  string
+		args  []string
+		env   string
+		check func(*testing.T, structBindingOptions)
+	}{
+		{
+			name: "Defaults",
+			args: []string{"my-command"},
+			check: func(t *testing.T, o structBindingOptions) {
+				if o.Count != 3 || o.Timeout != 30*time.Second || o.DryRun || o.Size != 0 || o.Labels != nil {
+					t.Errorf("Options = %+v", o)
+				}
+			},
+		},
+		{
+			name: "Command line",
+			args: []string{"my-command", "-c", "5", "--dry-run", "--size=1k", "--label=a", "--label=b"},
+			env:  "7",
+			check: func(t *testing.T, o structBindingOptions) {
+				if o.Count != 5 || !o.DryRun || o.Size != 1000 || strings.Join(o.Labels, ";") != "a;b" {
+					t.Errorf("Options = %+v", o)
+				}
+			},
+		},
+		{
+			name: "Environment",
+			args: []string{"my-command"},
+			env:  "7",
+			check: func(t *testing.T, o structBindingOptions) {
+				if o.Count != 7 {
+					t.Errorf("Options.Count = %d, want 7", o.Count)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.env != "" {
+				os.Setenv("TEST_COMMANDER_COUNT", tt.env)
+				defer os.Unsetenv("TEST_COMMANDER_COUNT")
+			}
+
+			command := &structBindingCommand{}
+			os.Args = append([]string{"/some/random/path/my-executable"}, tt.args...)
+			c := NewCommandRegistry()
+			c.Register(func(appName string) *CommandWrapper {
+				return &CommandWrapper{
+					Handler: command,
+					Options: &command.Options,
+					Help:    &CommandDescriptor{Name: "my-command"},
+				}
+			})
+
+			mockOutput = ""
+			c.Execute()
+
+			if !command.called {
+				t.Fatalf("command was not executed, output(%s)", mockOutput)
+			}
+			tt.check(t, command.Options)
+		})
+	}
+}
+
+func TestArgumentsFromStruct_narrowNumbers(t *testing.T) {
+	mockEverything()
+
+	options := &struct {
+		Small int8    `cmd:""`
+		Port  uint16  `cmd:"max=1024"`
+		Ratio float32 `cmd:""`
+		Big   int64   `cmd:""`
+	}{}
+
+	arguments, err := ArgumentsFromStruct(options)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := [][2]string{{"-128", "127"}, {"", "1024"}, {"-3.4028234663852886e+38", "3.4028234663852886e+38"}, {"", ""}}
+	for index, arg := range arguments {
+		if arg.Min != want[index][0] || arg.Max != want[index][1] {
+			t.Errorf("%s: Min, Max = %q, %q, want %q, %q", arg.Name, arg.Min, arg.Max, want[index][0], want[index][1])
+		}
+	}
+
+	c := &CommandHelper{}
+	c.AttachArgumentList(arguments)
+	if errs := c.parse([]string{"cmd", "--small=300"}); len(errs) != 0 {
+		t.Fatalf("parse() = %v, the argument is not FailOnError", errs)
+	}
+	if err := c.ErrorForTypedOpt("small"); err == nil || !strings.Contains(err.Error(), "127") {
+		t.Errorf("ErrorForTypedOpt(small) = %v, want out of range error", err)
+	}
+
+	arguments[0].Value, arguments[0].Error = int64(300), nil
+	if err := BindArguments(options, arguments); err == nil || options.Small != 0 {
+		t.Errorf("BindArguments() = %v, Small = %d, want overflow error", err, options.Small)
+	}
+}
+
+func TestFieldArgumentName(t *testing.T) {
+	tests := map[string]string{
+		"DryRun":     "dry-run",
+		"URL":        "url",
+		"HTTPPort":   "http-port",
+		"ServerURL":  "server-url",
+		"APIKeyFile": "api-key-file",
+		"Retry2Max":  "retry2-max",
+		"X":          "x",
+	}
+	for fieldName, want := range tests {
+		field := reflect.StructField{Name: fieldName}
+		if got := fieldArgumentName(field, ""); got != want {
+			t.Errorf("fieldArgumentName(%s) = %q, want %q", fieldName, got, want)
+		}
+	}
+
+	if got := fieldArgumentName(reflect.StructField{Name: "URL"}, "name=endpoint"); got != "endpoint" {
+		t.Errorf("fieldArgumentName(URL) = %q, want endpoint from the tag", got)
+	}
+}
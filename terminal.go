@@ -0,0 +1,40 @@
+package commander
+
+import (
+	"os"
+	"strconv"
+)
+
+// OutputIsTerminal reports whether the standard output is a terminal
+var OutputIsTerminal = func() bool {
+	info, err := os.Stdout.Stat()
+	if err != nil {
+		return false
+	}
+
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+// TerminalSize returns the width and height of the terminal
+// attached to the standard output
+var TerminalSize = terminalSize
+
+// terminalWidth returns the width of the output in columns.
+// COLUMNS environment variable overrides the detected value.
+// Returns with 0 if output is not a terminal and COLUMNS is not defined.
+func terminalWidth() int {
+	if columns, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && columns > 0 {
+		return columns
+	}
+
+	if !OutputIsTerminal() {
+		return 0
+	}
+
+	width, _, err := TerminalSize()
+	if err != nil {
+		return 0
+	}
+
+	return width
+}
@@ -0,0 +1,10 @@
+//go:build !darwin && !dragonfly && !freebsd && !linux && !netbsd && !openbsd
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package commander
+
+import "errors"
+
+func terminalSize() (width, height int, err error) {
+	return 0, 0, errors.New("terminal size detection is not supported")
+}
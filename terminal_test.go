@@ -0,0 +1,42 @@
+package commander
+
+import (
+	"errors"
+	"os"
+	"testing"
+)
+
+func TestTerminalWidth(t *testing.T) {
+	tests := []struct {
+		name     string
+		columns  string
+		terminal bool
+		size     int
+		sizeErr  error
+		want     int
+	}{
+		{name: "Not a terminal", want: 0},
+		{name: "COLUMNS override", columns: "42", want: 42},
+		{name: "Invalid COLUMNS", columns: "wide", terminal: true, size: 100, want: 100},
+		{name: "Terminal", terminal: true, size: 120, want: 120},
+		{name: "Terminal size error", terminal: true, sizeErr: errors.New("nope"), want: 0},
+	}
+
+	oldIsTerminal, oldSize := OutputIsTerminal, TerminalSize
+	defer func() {
+		OutputIsTerminal, TerminalSize = oldIsTerminal, oldSize
+		os.Unsetenv("COLUMNS")
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			os.Setenv("COLUMNS", tt.columns)
+			OutputIsTerminal = func() bool { return tt.terminal }
+			TerminalSize = func() (int, int, error) { return tt.size, 24, tt.sizeErr }
+
+			if got := terminalWidth(); got != tt.want {
+				t.Errorf("terminalWidth() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package commander
+
+import (
+	"regexp"
+	"unicode/utf8"
+)
+
+// Theme defines how Help, CommandHelp and error messages are styled.
+// Styles are ANSI SGR parameters ("1" for bold, "31" for red,
+// "1;36" for bold cyan), empty style means plain text.
+type Theme struct {
+	// CommandStyle is used for command names and signatures
+	CommandStyle string
+	// OptionStyle is used for option names
+	OptionStyle string
+	// HeadingStyle is used for section headings
+	HeadingStyle string
+	// ErrorStyle is used for error messages
+	ErrorStyle string
+}
+
+// DefaultTheme is used if CommandRegistry.Theme is not defined
+var DefaultTheme = &Theme{
+	CommandStyle: "1",
+	OptionStyle:  "36",
+	HeadingStyle: "1",
+	ErrorStyle:   "31",
+}
+
+// plainTheme is used when colors are disabled
+var plainTheme = &Theme{}
+
+var ansiSequence = regexp.MustCompile("\x1b\\[[0-9;]*m")
+
+// Command styles a command name or signature
+func (t *Theme) Command(text string) string {
+	return paint(t.CommandStyle, text)
+}
+
+// Option styles an option name
+func (t *Theme) Option(text string) string {
+	return paint(t.OptionStyle, text)
+}
+
+// Heading styles a section heading
+func (t *Theme) Heading(text string) string {
+	return paint(t.HeadingStyle, text)
+}
+
+// Error styles an error message
+func (t *Theme) Error(text string) string {
+	return paint(t.ErrorStyle, text)
+}
+
+func paint(style, text string) string {
+	if style == "" || text == "" {
+		return text
+	}
+
+	return "\x1b[" + style + "m" + text + "\x1b[0m"
+}
+
+// visibleLength returns the length of text without ANSI sequences
+func visibleLength(text string) int {
+	return utf8.RuneCountInString(ansiSequence.ReplaceAllString(text, ""))
+}
@@ -0,0 +1,71 @@
+package commander
+
+import (
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestTheme(t *testing.T) {
+	theme := &Theme{CommandStyle: "1", ErrorStyle: "31"}
+
+	if got := theme.Command("cmd"); got != "\x1b[1mcmd\x1b[0m" {
+		t.Errorf("Theme.Command() = %q", got)
+	}
+
+	if got := theme.Error("failed"); got != "\x1b[31mfailed\x1b[0m" {
+		t.Errorf("Theme.Error() = %q", got)
+	}
+
+	if got := theme.Option("--opt"); got != "--opt" {
+		t.Errorf("Theme.Option() = %q, want plain text", got)
+	}
+
+	if got := visibleLength(theme.Command("cmd")); got != 3 {
+		t.Errorf("visibleLength() = %d, want 3", got)
+	}
+}
+
+func TestCommandRegistry_colors(t *testing.T) {
+	tests := []struct {
+		name     string
+		cliArgs  []string
+		noColor  string
+		terminal bool
+		colored  bool
+	}{
+		{name: "Terminal", cliArgs: []string{"invalid-call"}, terminal: true, colored: true},
+		{name: "Not a terminal", cliArgs: []string{"invalid-call"}, terminal: false, colored: false},
+		{name: "NO_COLOR", cliArgs: []string{"invalid-call"}, noColor: "1", terminal: true, colored: false},
+		{name: "--no-color", cliArgs: []string{"invalid-call", "--no-color"}, terminal: true, colored: false},
+	}
+
+	oldArgs := os.Args
+	defer func() {
+		os.Args = oldArgs
+		mockEverything()
+	}()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockEverything()
+			OutputIsTerminal = func() bool { return tt.terminal }
+			os.Setenv("NO_COLOR", tt.noColor)
+			os.Args = append([]string{"/some/random/path/my-executable"}, tt.cliArgs...)
+
+			c := NewCommandRegistry()
+			c.Theme = &Theme{ErrorStyle: "31", CommandStyle: "1"}
+			c.Execute()
+
+			values := []string{
+				"\x1b[31mCommand not found: invalid-call\x1b[0m",
+				"\x1b[1mhelp [command]\x1b[0m   Display",
+			}
+			for _, value := range values {
+				if strings.Contains(mockOutput, value) != tt.colored {
+					t.Errorf("value(%q) colored=%v in output(%q)", value, tt.colored, mockOutput)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrUnknownArgumentType is returned by SetValue if the type
+// of the argument is not registered
+var ErrUnknownArgumentType = errors.New("unknown argument type")
+
+// ErrReadOnlyTypeRegistry is returned when a type is registered
+// into the read-only set of built-in types
+var ErrReadOnlyTypeRegistry = errors.New("type registry is read-only")
+
+// DuplicateTypeError is returned when a type name is already
+// registered in a TypeRegistry or in one of its parents
+type DuplicateTypeError struct {
+	Name string
+}
+
+func (e *DuplicateTypeError) Error() string {
+	return fmt.Sprintf("argument type %s is already registered", e.Name)
+}
+
+// TypeRegistry is a set of argument types. Types not found in the
+// registry are looked up in its parent. It's safe for concurrent use.
+//
+// Registration fails if the name is already visible in the registry,
+// but parents don't know their children: a type registered later in
+// a parent (RegisterArgumentType) with the same name is shadowed by
+// the type of the child registry.
+type TypeRegistry struct {
+	parent   *TypeRegistry
+	readOnly bool
+
+	mutex sync.RWMutex
+	types map[string]typeEntry
+}
+
+// typeEntry is a registered argument type
+type typeEntry struct {
+	convert argumentTypeFunction
+	// list types accept repeated options
+	list bool
+	// sideEffects marks converters touching the file system,
+	// they are not run just to format values in help
+	sideEffects bool
+}
+
+// builtinTypes is the read-only set of built-in types
+var builtinTypes = &TypeRegistry{readOnly: true}
+
+// sharedTypes are the types registered with RegisterArgumentType,
+// every CommandRegistry inherits them
+var sharedTypes = NewTypeRegistry(builtinTypes)
+
+// NewTypeRegistry creates an empty TypeRegistry inheriting the types
+// of parent. If parent is nil, it inherits the built-in types.
+func NewTypeRegistry(parent *TypeRegistry) *TypeRegistry {
+	if parent == nil {
+		parent = builtinTypes
+	}
+
+	return &TypeRegistry{parent: parent}
+}
+
+// Register registers a new argument type. It fails if the name is
+// already registered in this registry or in one of its parents.
+func (r *TypeRegistry) Register(name string, f func(string) (interface{}, error)) error {
+	return r.add(name, typeEntry{convert: f})
+}
+
+// RegisterList registers a new argument type that accepts
+// comma separated values. If the option is repeated
+// (--set a=1 --set b=2), all values are joined with commas.
+func (r *TypeRegistry) RegisterList(name string, f func(string) (interface{}, error)) error {
+	return r.add(name, typeEntry{convert: f, list: true})
+}
+
+// Has reports whether the type is available in the registry:
+// registered in it or in one of its parents, or a "T[]" list
+// of an available type T
+func (r *TypeRegistry) Has(name string) bool {
+	return r.converter(name) != nil
+}
+
+func (r *TypeRegistry) add(name string, entry typeEntry) error {
+	if r.readOnly {
+		return ErrReadOnlyTypeRegistry
+	}
+
+	return r.register(name, entry)
+}
+
+func registerBuiltinType(name string, f argumentTypeFunction) {
+	mustRegisterBuiltin(name, typeEntry{convert: f})
+}
+
+func registerBuiltinListType(name string, f argumentTypeFunction) {
+	mustRegisterBuiltin(name, typeEntry{convert: f, list: true})
+}
+
+// registerBuiltinFileType registers a type with a converter
+// touching the file system
+func registerBuiltinFileType(name string, f argumentTypeFunction) {
+	mustRegisterBuiltin(name, typeEntry{convert: f, sideEffects: true})
+}
+
+func mustRegisterBuiltin(name string, entry typeEntry) {
+	if err := builtinTypes.register(name, entry); err != nil {
+		panic(err)
+	}
+}
+
+// register adds the type without checking readOnly,
+// it's used to fill builtinTypes. The parents are checked
+// while the lock of r is held (locks are always taken
+// from child to parent).
+func (r *TypeRegistry) register(name string, entry typeEntry) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, found := r.types[name]; found {
+		return &DuplicateTypeError{Name: name}
+	}
+
+	if r.parent != nil {
+		if _, found := r.parent.lookup(name); found {
+			return &DuplicateTypeError{Name: name}
+		}
+	}
+
+	if r.types == nil {
+		r.types = map[string]typeEntry{}
+	}
+
+	r.types[name] = entry
+
+	return nil
+}
+
+// lookup returns with the registered type
+func (r *TypeRegistry) lookup(name string) (typeEntry, bool) {
+	r.mutex.RLock()
+	entry, found := r.types[name]
+	r.mutex.RUnlock()
+
+	if found {
+		return entry, true
+	}
+
+	if r.parent != nil {
+		return r.parent.lookup(name)
+	}
+
+	return typeEntry{}, false
+}
+
+// converter returns with the converter of the given type.
+// Unregistered "T[]" types are lists of the registered type T:
+// Int64[] is []int64, IP[] is []net.IP
+func (r *TypeRegistry) converter(name string) argumentTypeFunction {
+	if entry, found := r.lookup(name); found {
+		return entry.convert
+	}
+
+	if !strings.HasSuffix(name, arrayTypeSuffix) {
+		return nil
+	}
+
+	element := r.converter(strings.TrimSuffix(name, arrayTypeSuffix))
+	if element == nil {
+		return nil
+	}
+
+	return func(value string) (interface{}, error) {
+		return parseArray(value, element)
+	}
+}
+
+// isList reports whether the type accepts repeated options
+func (r *TypeRegistry) isList(name string) bool {
+	entry, _ := r.lookup(name)
+
+	return entry.list || strings.HasSuffix(name, arrayTypeSuffix)
+}
+
+// hasSideEffects reports whether the converter of the type
+// (or of the items of a "T[]" type) touches the file system
+func (r *TypeRegistry) hasSideEffects(name string) bool {
+	if entry, found := r.lookup(name); found {
+		return entry.sideEffects
+	}
+
+	if strings.HasSuffix(name, arrayTypeSuffix) {
+		return r.hasSideEffects(strings.TrimSuffix(name, arrayTypeSuffix))
+	}
+
+	return false
+}
@@ -0,0 +1,191 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestTypeRegistry_Register(t *testing.T) {
+	parent := NewTypeRegistry(nil)
+	child := NewTypeRegistry(parent)
+
+	upper := func(value string) (interface{}, error) {
+		return strings.ToUpper(value), nil
+	}
+
+	if err := parent.Register("Upper", upper); err != nil {
+		t.Fatal(err)
+	}
+
+	if !child.Has("Upper") || !child.Has("Upper[]") || !child.Has("Duration") {
+		t.Error("TypeRegistry should inherit the types of its parent")
+	}
+
+	if parent.Has("Lower") || NewTypeRegistry(nil).Has("Upper") {
+		t.Error("TypeRegistry should not see types of its children or siblings")
+	}
+
+	var duplicate *DuplicateTypeError
+	for _, name := range []string{"Upper", "Duration"} {
+		if err := child.Register(name, upper); !errors.As(err, &duplicate) || duplicate.Name != name {
+			t.Errorf("TypeRegistry.Register(%s) = %v, want DuplicateTypeError", name, err)
+		}
+	}
+
+	if err := builtinTypes.Register("Upper", upper); err != ErrReadOnlyTypeRegistry {
+		t.Errorf("builtinTypes.Register() = %v, want ErrReadOnlyTypeRegistry", err)
+	}
+
+	// parents don't know their children, a later type of the parent is shadowed
+	if err := child.Register("Shadow", upper); err != nil {
+		t.Fatal(err)
+	}
+	lower := func(value string) (interface{}, error) {
+		return strings.ToLower(value), nil
+	}
+	if err := parent.Register("Shadow", lower); err != nil {
+		t.Fatal(err)
+	}
+	if value, _ := child.converter("Shadow")("Aa"); value != "AA" {
+		t.Errorf("child converter(Shadow) = %v, want the type of the child", value)
+	}
+
+	if err := child.RegisterList("UpperList", upper); err != nil || !child.isList("UpperList") || child.isList("Upper") {
+		t.Errorf("TypeRegistry.RegisterList() = %v, list type not detected", err)
+	}
+}
+
+func TestTypeRegistry_RegisterSpecial(t *testing.T) {
+	r := NewTypeRegistry(nil)
+
+	if err := r.RegisterJSON("Point", struct{ X, Y int }{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterURL("WebURL", "http", "https"); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.RegisterPath("Config", PathMustExist|PathRegularFile); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, name := range []string{"Point", "WebURL", "Config"} {
+		if !r.Has(name) || sharedTypes.Has(name) {
+			t.Errorf("%s should be registered only in the registry", name)
+		}
+	}
+
+	if _, err := r.converter("WebURL")("ftp://example.com"); err == nil {
+		t.Error("WebURL should reject the ftp scheme")
+	}
+
+	if !r.hasSideEffects("Config") {
+		t.Error("path types should be marked with side effects")
+	}
+}
+
+func TestTypeRegistry_concurrent(t *testing.T) {
+	r := NewTypeRegistry(nil)
+
+	var wg sync.WaitGroup
+	for index := 0; index < 20; index++ {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			name := fmt.Sprintf("Type%d", index%10)
+			_ = r.Register(name, func(value string) (interface{}, error) {
+				return value, nil
+			})
+			r.Has(name)
+		}(index)
+	}
+	wg.Wait()
+
+	for index := 0; index < 10; index++ {
+		if !r.Has(fmt.Sprintf("Type%d", index)) {
+			t.Errorf("Type%d is not registered", index)
+		}
+	}
+}
+
+func TestTypeRegistry_hasSideEffects(t *testing.T) {
+	if err := RegisterPathArgumentType("TestConfigFile", PathMustExist|PathRegularFile); err != nil {
+		t.Fatal(err)
+	}
+
+	r := NewTypeRegistry(sharedTypes)
+	for name, want := range map[string]bool{
+		"Input":          true,
+		"Content":        true,
+		"FilePath[]":     true,
+		"TestConfigFile": true,
+		"ByteSize":       false,
+		"StringMap":      false,
+		"NoSuchType":     false,
+	} {
+		if got := r.hasSideEffects(name); got != want {
+			t.Errorf("TypeRegistry.hasSideEffects(%s) = %v, want %v", name, got, want)
+		}
+	}
+
+	// help is rendered without reading the default file
+	arg := &Argument{Name: "body", Type: "Content", Default: "@/no/such/file"}
+	if got := arg.formatBound(arg.Default); got != arg.Default {
+		t.Errorf("Argument.formatBound() = %s, want %s", got, arg.Default)
+	}
+}
+
+func TestArgument_SetValue_unknownType(t *testing.T) {
+	arg := &Argument{Name: "a", Type: "NoSuchType"}
+	if err := arg.SetValue("x"); !errors.Is(err, ErrUnknownArgumentType) {
+		t.Errorf("Argument.SetValue() = %v, want ErrUnknownArgumentType", err)
+	}
+}
+
+type typeRegistryCommand struct {
+	name interface{}
+}
+
+func (c *typeRegistryCommand) Execute(opts *CommandHelper) {
+	c.name = opts.TypedOpt("name")
+}
+
+func TestCommandRegistry_Types(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"/some/random/path/my-executable", "my-command", "--name=abc"}
+	c := NewCommandRegistry()
+	err := c.Types.Register("Upper", func(value string) (interface{}, error) {
+		return strings.ToUpper(value), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	command := &typeRegistryCommand{}
+	c.Register(func(appName string) *CommandWrapper {
+		return &CommandWrapper{
+			Handler: command,
+			Arguments: []*Argument{
+				&Argument{Name: "name", Type: "Upper", FailOnError: true},
+			},
+			Help: &CommandDescriptor{Name: "my-command"},
+		}
+	})
+
+	mockOutput = ""
+	c.Execute()
+	if command.name != "ABC" {
+		t.Errorf("TypedOpt(name) = %v, want ABC, output(%s)", command.name, mockOutput)
+	}
+
+	if NewCommandRegistry().Types.Has("Upper") {
+		t.Error("types of a CommandRegistry should not be visible in other registries")
+	}
+}
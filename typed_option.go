@@ -0,0 +1,98 @@
+package commander
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrAbsentOption is returned by Get if the option is not declared,
+// or it's not passed and has no default value
+var ErrAbsentOption = errors.New("option is absent")
+
+// InvalidOptionError is returned by Get if the value
+// of the option could not be converted or validated
+type InvalidOptionError struct {
+	Name string
+	Err  error
+}
+
+func (e *InvalidOptionError) Error() string {
+	return fmt.Sprintf("invalid option --%s: %s", e.Name, e.Err)
+}
+
+// Unwrap returns with the conversion or validation error
+func (e *InvalidOptionError) Unwrap() error {
+	return e.Err
+}
+
+// TypeMismatchError is returned by Get if the value
+// of the option has a different type than requested
+type TypeMismatchError struct {
+	Name string
+	// Type is the argument type of the option: Int64
+	Type string
+	// Got is the Go type of the value: int64
+	Got string
+	// Want is the requested Go type: string
+	Want string
+}
+
+func (e *TypeMismatchError) Error() string {
+	return fmt.Sprintf("option --%s is %s (%s), not %s", e.Name, e.Type, e.Got, e.Want)
+}
+
+// Get returns with the typed value of an option. The zero value of T
+// is returned with ErrAbsentOption if the option has no value from any
+// source (see CommandHelper.Source), with an *InvalidOptionError if the
+// value is invalid and with a *TypeMismatchError if the value is not a T.
+//
+//	count, err := commander.Get[int64](opts, "count")
+func Get[T any](c *CommandHelper, key string) (T, error) {
+	var zero T
+
+	arg := c.argument(key)
+	if arg == nil || (arg.Source.Kind == SourceNone && arg.OriginalValue == "") {
+		return zero, fmt.Errorf("--%s: %w", key, ErrAbsentOption)
+	}
+
+	if arg.Error != nil {
+		return zero, &InvalidOptionError{Name: key, Err: arg.exposedError()}
+	}
+
+	// a passed nil value (JSON null) is the zero value of nillable types
+	if arg.Value == nil && isNillable(reflect.TypeOf((*T)(nil)).Elem()) {
+		return zero, nil
+	}
+
+	value, ok := arg.exposedValue().(T)
+	if !ok {
+		return zero, &TypeMismatchError{
+			Name: key,
+			Type: arg.Type,
+			Got:  fmt.Sprintf("%T", arg.exposedValue()),
+			Want: reflect.TypeOf((*T)(nil)).Elem().String(),
+		}
+	}
+
+	return value, nil
+}
+
+// MustGet is like Get but panics if the option has no valid T value
+func MustGet[T any](c *CommandHelper, key string) T {
+	value, err := Get[T](c, key)
+	if err != nil {
+		panic(err)
+	}
+
+	return value
+}
+
+func isNillable(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Interface, reflect.Ptr, reflect.Slice, reflect.Map, reflect.Func, reflect.Chan:
+		return true
+	}
+
+	return false
+}
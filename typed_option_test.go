@@ -0,0 +1,89 @@
+package commander
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func newTypedOptionHelper() *CommandHelper {
+	c := &CommandHelper{}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "count", Type: "Int64"},
+		&Argument{Name: "size", Type: "ByteSize"},
+		&Argument{Name: "list", Type: "StringArray[]"},
+		&Argument{Name: "name", Type: "String"},
+	})
+	c.Parse([]string{"cmd", "--count=3", "--size=nope", "--list=a,b"})
+
+	return c
+}
+
+func TestGet(t *testing.T) {
+	c := newTypedOptionHelper()
+
+	count, err := Get[int64](c, "count")
+	if count != 3 || err != nil {
+		t.Errorf("Get[int64](count) = %v, %v, want 3, nil", count, err)
+	}
+
+	list, err := Get[[]string](c, "list")
+	if strings.Join(list, ",") != "a,b" || err != nil {
+		t.Errorf("Get[[]string](list) = %v, %v, want [a b], nil", list, err)
+	}
+
+	for _, key := range []string{"name", "no-key"} {
+		name, err := Get[string](c, key)
+		if name != "" || !errors.Is(err, ErrAbsentOption) {
+			t.Errorf("Get[string](%s) = %q, %v, want ErrAbsentOption", key, name, err)
+		}
+	}
+
+	size, err := Get[ByteSize](c, "size")
+	var invalid *InvalidOptionError
+	if size != 0 || !errors.As(err, &invalid) || invalid.Name != "size" {
+		t.Errorf("Get[ByteSize](size) = %v, %v, want InvalidOptionError", size, err)
+	}
+
+	text, err := Get[string](c, "count")
+	var mismatch *TypeMismatchError
+	if text != "" || !errors.As(err, &mismatch) {
+		t.Fatalf("Get[string](count) = %q, %v, want TypeMismatchError", text, err)
+	}
+	if want := "option --count is Int64 (int64), not string"; err.Error() != want {
+		t.Errorf("TypeMismatchError.Error() = %q, want %q", err.Error(), want)
+	}
+}
+
+func TestGet_null(t *testing.T) {
+	c := &CommandHelper{}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "body", Type: "JSON"},
+	})
+	c.Parse([]string{"cmd", "--body=null"})
+
+	body, err := Get[interface{}](c, "body")
+	if body != nil || err != nil {
+		t.Errorf("Get[interface{}](body) = %v, %v, want nil, nil", body, err)
+	}
+
+	var mismatch *TypeMismatchError
+	if _, err := Get[float64](c, "body"); !errors.As(err, &mismatch) {
+		t.Errorf("Get[float64](body) error = %v, want TypeMismatchError", err)
+	}
+}
+
+func TestMustGet(t *testing.T) {
+	c := newTypedOptionHelper()
+
+	if got := MustGet[int64](c, "count"); got != 3 {
+		t.Errorf("MustGet[int64](count) = %v, want 3", got)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Error("MustGet[string](name) should panic")
+		}
+	}()
+	MustGet[string](c, "name")
+}
@@ -0,0 +1,51 @@
+package commander
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ValidationErrors collects all the problems of a command call:
+// invalid and missing arguments, option rules and validator errors
+type ValidationErrors []error
+
+// Error returns with all the errors, one per line
+func (v ValidationErrors) Error() string {
+	messages := make([]string, 0, len(v))
+	for _, err := range v {
+		messages = append(messages, err.Error())
+	}
+
+	return strings.Join(messages, "\n")
+}
+
+// Add appends err to the list, ValidationErrors are flattened
+// and nil is ignored
+func (v *ValidationErrors) Add(err error) {
+	switch e := err.(type) {
+	case nil:
+		return
+	case ValidationErrors:
+		*v = append(*v, e...)
+	default:
+		*v = append(*v, err)
+	}
+}
+
+// ArgumentError is an invalid or missing Argument
+type ArgumentError struct {
+	Argument *Argument
+	// Missing is true if a Required Argument is not passed
+	Missing bool
+}
+
+func (e *ArgumentError) Error() string {
+	if e.Missing {
+		return fmt.Sprintf("Missing argument: --%s", e.Argument.Name)
+	}
+
+	return fmt.Sprintf(
+		"Invalid argument: --%s=%s [%s]",
+		e.Argument.Name, e.Argument.displayValue(), e.Argument.exposedError(),
+	)
+}
@@ -0,0 +1,117 @@
+package commander
+
+import (
+	"errors"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestValidationErrors_Add(t *testing.T) {
+	errs := ValidationErrors{}
+	errs.Add(nil)
+	errs.Add(errors.New("first"))
+	errs.Add(ValidationErrors{errors.New("second"), errors.New("third")})
+
+	if len(errs) != 3 {
+		t.Errorf("len(ValidationErrors) = %d, want 3", len(errs))
+	}
+
+	if got := errs.Error(); got != "first\nsecond\nthird" {
+		t.Errorf("ValidationErrors.Error() = %q", got)
+	}
+}
+
+func TestCommandHelper_Parse_validationErrors(t *testing.T) {
+	c := &CommandHelper{}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "count", Type: "Int64", FailOnError: true},
+		&Argument{Name: "port", Type: "Int64", Max: "65535", FailOnError: true},
+		&Argument{Name: "name", Type: "String", Required: true},
+		&Argument{Name: "size", Type: "Int64", Required: true, Default: "3"},
+	})
+
+	defer func() {
+		errs, ok := recover().(ValidationErrors)
+		if !ok {
+			t.Fatal("CommandHelper.Parse() should panic with ValidationErrors")
+		}
+
+		want := "Invalid argument: --count=many [strconv.ParseInt: parsing \"many\": invalid syntax]\n" +
+			"Invalid argument: --port=70000 [must be at most 65535]\n" +
+			"Missing argument: --name"
+		if errs.Error() != want {
+			t.Errorf("ValidationErrors = %q, want %q", errs.Error(), want)
+		}
+	}()
+
+	c.Parse([]string{"command", "--count=many", "--port=70000"})
+}
+
+func TestCommandHelper_Parse_warnings(t *testing.T) {
+	mockEverything()
+
+	c := &CommandHelper{}
+	c.AttachArgumentList([]*Argument{
+		&Argument{Name: "count", Type: "Int64"},
+		&Argument{Name: "port", Type: "Int64", Max: "65535"},
+	})
+	c.Parse([]string{"command", "--count=many", "--port=70000"})
+
+	want := "Invalid argument: --count=many [strconv.ParseInt: parsing \"many\": invalid syntax]\n" +
+		"Invalid argument: --port=70000 [must be at most 65535]"
+	if c.Warnings.Error() != want {
+		t.Errorf("CommandHelper.Warnings = %q, want %q", c.Warnings.Error(), want)
+	}
+
+	if mockOutput != "" {
+		t.Errorf("output(%s), want nothing printed while parsing", mockOutput)
+	}
+}
+
+func TestCommandRegistry_validationErrors(t *testing.T) {
+	mockEverything()
+
+	oldArgs := os.Args
+	defer func() { os.Args = oldArgs }()
+
+	os.Args = []string{"/some/random/path/my-executable", "my-command", "-v", "--count=many", "--all", "--name=x"}
+	executeCalled = false
+	validatorCalled = false
+	c := NewCommandRegistry()
+	c.Register(func(appName string) *CommandWrapper {
+		return &CommandWrapper{
+			Handler: &MyCommand{},
+			Help:    &CommandDescriptor{Name: "my-command"},
+			Arguments: []*Argument{
+				&Argument{Name: "count", Type: "Int64", FailOnError: true},
+				&Argument{Name: "user", Type: "String", Required: true},
+			},
+			Rules:     []OptionRule{Conflicts("all", "name")},
+			Validator: myValidatoFunction,
+			Validate: func(opts *CommandHelper) error {
+				return ValidationErrors{errors.New("first problem"), errors.New("second problem")}
+			},
+		}
+	})
+	c.Execute()
+
+	if executeCalled {
+		t.Error("Command should not be called")
+	}
+
+	if !validatorCalled {
+		t.Error("Command preValidator should be called")
+	}
+
+	want := "[E] Invalid argument: --count=many [strconv.ParseInt: parsing \"many\": invalid syntax]\n" +
+		"[E] Missing argument: --user\n" +
+		"[E] --all and --name cannot be used together\n" +
+		"[E] Sad panda\n" +
+		"[E] first problem\n" +
+		"[E] second problem\n\n" +
+		"Usage: my-executable my-command"
+	if !strings.HasPrefix(mockOutput, want) {
+		t.Errorf("output(%s), want prefix(%s)", mockOutput, want)
+	}
+}